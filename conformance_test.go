@@ -0,0 +1,378 @@
+package iter_test
+
+import (
+	"cmp"
+	"context"
+	stdIter "iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommoulard/iter"
+)
+
+// kv is a pair of elements yielded by an iter.Seq2.
+type kv[T, U any] struct {
+	K T
+	V U
+}
+
+// pairs returns a sequence of the pairs of elements of the input sequence.
+func pairs[T, U any](seq stdIter.Seq2[T, U]) stdIter.Seq[kv[T, U]] {
+	return func(yield func(kv[T, U]) bool) {
+		for k, v := range seq {
+			if !yield(kv[T, U]{K: k, V: v}) {
+				return
+			}
+		}
+	}
+}
+
+// sorted returns a sequence of the sorted elements of the input sequence, to
+// compare sequences whose order is not specified.
+func sorted[T cmp.Ordered](seq stdIter.Seq[T]) stdIter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, elem := range slices.Sorted(seq) {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// assertReiterable asserts that the sequence yields the same elements each
+// time it is ranged over, including after an iteration that stopped early.
+func assertReiterable[T any](t *testing.T, seq stdIter.Seq[T]) {
+	t.Helper()
+
+	first := iter.Values(seq)
+	require.NotEmpty(t, first, "the sequence must not be empty to be tested")
+
+	assert.Equal(t, first, iter.Values(seq), "second iteration")
+
+	for range seq {
+		break
+	}
+
+	assert.Equal(t, first, iter.Values(seq), "iteration after a break")
+}
+
+// assertReiterable2 asserts that the sequence yields the same pairs of
+// elements each time it is ranged over, including after an iteration that
+// stopped early.
+func assertReiterable2[T, U any](t *testing.T, seq stdIter.Seq2[T, U]) {
+	t.Helper()
+
+	assertReiterable(t, pairs(seq))
+}
+
+// rangeN ranges over the sequence until n elements are yielded, and returns
+// them along with the number of calls to yield made after it returned false.
+// The sequence is called directly rather than with a range loop, so that such
+// calls are counted instead of making the runtime panic.
+func rangeN[T any](seq stdIter.Seq[T], n int) (got []T, extra int) {
+	stopped := false
+
+	seq(func(elem T) bool {
+		if stopped {
+			extra++
+
+			return false
+		}
+
+		got = append(got, elem)
+		stopped = len(got) == n
+
+		return !stopped
+	})
+
+	return got, extra
+}
+
+// assertStops asserts that the sequence stops as soon as its yield function
+// returns false, after each of its elements.
+func assertStops[T any](t *testing.T, seq stdIter.Seq[T]) {
+	t.Helper()
+
+	all := iter.Values(seq)
+	require.NotEmpty(t, all, "the sequence must not be empty to be tested")
+
+	for n := 1; n <= len(all); n++ {
+		got, extra := rangeN(seq, n)
+		assert.Equal(t, all[:n], got, "break after %d elements", n)
+		assert.Zero(t, extra, "calls to yield after a break after %d elements", n)
+	}
+}
+
+// assertConforms asserts that the sequence is re-iterable, and that it stops
+// as soon as its yield function returns false.
+func assertConforms[T any](t *testing.T, seq stdIter.Seq[T]) {
+	t.Helper()
+
+	assertReiterable(t, seq)
+	assertStops(t, seq)
+}
+
+// assertConforms2 is assertConforms for an iter.Seq2.
+func assertConforms2[T, U any](t *testing.T, seq stdIter.Seq2[T, U]) {
+	t.Helper()
+
+	assertConforms(t, pairs(seq))
+}
+
+// assertConformsUnordered is assertConforms for a sequence whose order is not
+// specified.
+func assertConformsUnordered[T cmp.Ordered](t *testing.T, seq stdIter.Seq[T]) {
+	t.Helper()
+
+	assertReiterable(t, sorted(seq))
+
+	all := iter.Values(seq)
+	require.NotEmpty(t, all, "the sequence must not be empty to be tested")
+
+	for n := 1; n <= len(all); n++ {
+		got, extra := rangeN(seq, n)
+		assert.Len(t, got, n, "break after %d elements", n)
+		assert.Subset(t, all, got, "break after %d elements", n)
+		assert.Zero(t, extra, "calls to yield after a break after %d elements", n)
+	}
+}
+
+func conformanceCases() []struct {
+	name string
+	test func(t *testing.T)
+} {
+	isOdd := func(i int) bool { return i%2 == 1 }
+	isSmall := func(i int) bool { return i < 3 }
+	parity := func(i int) int { return i % 2 }
+	double := func(i int) int { return i * 2 }
+	add := func(a, b int) int { return a + b }
+	values := func() stdIter.Seq[int] { return slices.Values(ints(5)) }
+	values2 := func() stdIter.Seq2[int, string] {
+		return iter.Zip(ints(5), []string{"a", "b", "c", "d", "e"})
+	}
+	parallelDouble := func(_ context.Context, i int) (int, error) { return double(i), nil }
+	parallelIsOdd := func(_ context.Context, i int) (bool, error) { return isOdd(i), nil }
+	tryDouble := func(i int) (int, error) { return double(i), nil }
+	tryIsOdd := func(i int) (bool, error) { return isOdd(i), nil }
+
+	return []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{"Zip", func(t *testing.T) { assertConforms2(t, iter.Zip(ints(3), ints(4))) }},
+		{"IZip", func(t *testing.T) { assertConforms2(t, iter.IZip(values(), values())) }},
+		{"ZipLongest", func(t *testing.T) { assertConforms2(t, iter.ZipLongest(ints(3), ints(4), -1, -2)) }},
+		{"IZipLongest", func(t *testing.T) {
+			assertConforms2(t, iter.IZipLongest(values(), slices.Values(ints(3)), -1, -2))
+		}},
+		{"ZipLongestN", func(t *testing.T) { assertConforms(t, iter.ZipLongestN(-1, ints(3), ints(5), ints(1))) }},
+		{"IZipLongestN", func(t *testing.T) {
+			assertConforms(t, iter.IZipLongestN(-1, values(), slices.Values(ints(3))))
+		}},
+		{"Zip3", func(t *testing.T) { assertConforms(t, iter.Zip3(ints(3), ints(4), ints(5))) }},
+		{"IZip3", func(t *testing.T) { assertConforms(t, iter.IZip3(values(), values(), values())) }},
+		{"Zip4", func(t *testing.T) { assertConforms(t, iter.Zip4(ints(3), ints(4), ints(5), ints(3))) }},
+		{"IZip4", func(t *testing.T) { assertConforms(t, iter.IZip4(values(), values(), values(), values())) }},
+		{"ZipLongest3", func(t *testing.T) {
+			assertConforms(t, iter.ZipLongest3(ints(3), ints(4), ints(1), -1, -2, -3))
+		}},
+		{"IZipLongest3", func(t *testing.T) {
+			assertConforms(t, iter.IZipLongest3(values(), slices.Values(ints(2)), slices.Values(ints(3)), -1, -2, -3))
+		}},
+		{"ZipLongest4", func(t *testing.T) {
+			assertConforms(t, iter.ZipLongest4(ints(3), ints(4), ints(1), ints(2), -1, -2, -3, -4))
+		}},
+		{"IZipLongest4", func(t *testing.T) {
+			assertConforms(t, iter.IZipLongest4(values(), values(), slices.Values(ints(2)), slices.Values(ints(3)), -1, -2, -3, -4))
+		}},
+		{"ZipN", func(t *testing.T) { assertConforms(t, iter.ZipN(ints(3), ints(4), ints(5))) }},
+		{"IZipN", func(t *testing.T) { assertConforms(t, iter.IZipN(values(), values(), values())) }},
+		{"RoundRobin", func(t *testing.T) {
+			assertConforms(t, iter.RoundRobin(values(), slices.Values(ints(2)), slices.Values(ints(3))))
+		}},
+		{"Interleave", func(t *testing.T) {
+			assertConforms(t, iter.Interleave(values(), slices.Values(ints(3))))
+		}},
+		// Unzip returns single-use sequences, see TestUnzip.
+		{"Accumulate", func(t *testing.T) { assertConforms(t, iter.Accumulate(ints(5))) }},
+		{"IAccumulate", func(t *testing.T) { assertConforms(t, iter.IAccumulate(values())) }},
+		{"AccumulateFunc", func(t *testing.T) { assertConforms(t, iter.AccumulateFunc(add, 1, values())) }},
+		{"Chain", func(t *testing.T) { assertConforms(t, iter.Chain(ints(2), ints(3))) }},
+		{"ChainSeq", func(t *testing.T) { assertConforms(t, iter.ChainSeq(values(), values())) }},
+		{"Compress", func(t *testing.T) {
+			assertConforms(t, iter.Compress(ints(3), []bool{true, false, true}))
+		}},
+		{"ICompress", func(t *testing.T) {
+			assertConforms(t, iter.ICompress(values(), slices.Values([]bool{true, false, true})))
+		}},
+		{"DropWhile", func(t *testing.T) { assertConforms(t, iter.DropWhile(isSmall, ints(5))) }},
+		{"IDropWhile", func(t *testing.T) { assertConforms(t, iter.IDropWhile(isSmall, values())) }},
+		{"Filter", func(t *testing.T) { assertConforms(t, iter.Filter(isOdd, ints(5))) }},
+		{"IFilter", func(t *testing.T) { assertConforms(t, iter.IFilter(isOdd, values())) }},
+		{"FilterFalse", func(t *testing.T) { assertConforms(t, iter.FilterFalse(isOdd, ints(5))) }},
+		{"IFilterFalse", func(t *testing.T) { assertConforms(t, iter.IFilterFalse(isOdd, values())) }},
+		{"GroupBy", func(t *testing.T) {
+			assertConforms2(t, iter.MapValues(iter.Values[int], iter.GroupBy(parity, ints(5))))
+		}},
+		{"IGroupBy", func(t *testing.T) {
+			assertConforms2(t, iter.MapValues(iter.Values[int], iter.IGroupBy(parity, values())))
+		}},
+		{"GroupByStable", func(t *testing.T) {
+			assertConforms2(t, iter.MapValues(iter.Values[int], iter.GroupByStable(parity, ints(5))))
+		}},
+		{"IGroupByStable", func(t *testing.T) {
+			assertConforms2(t, iter.MapValues(iter.Values[int], iter.IGroupByStable(parity, values())))
+		}},
+		{"Map", func(t *testing.T) { assertConforms(t, iter.Map(double, ints(5))) }},
+		{"Map2", func(t *testing.T) { assertConforms(t, iter.Map2(add, ints(5), ints(3))) }},
+		{"IMap", func(t *testing.T) { assertConforms(t, iter.IMap(double, values())) }},
+		{"IMap2", func(t *testing.T) { assertConforms(t, iter.IMap2(add, values(), values())) }},
+		{"TakeWhile", func(t *testing.T) { assertConforms(t, iter.TakeWhile(isSmall, ints(5))) }},
+		{"ITakeWhile", func(t *testing.T) { assertConforms(t, iter.ITakeWhile(isSmall, values())) }},
+		{"ChainMap", func(t *testing.T) {
+			assertConforms2(t, iter.ChainMap(map[int]int{1: 2, 3: 4}, map[int]int{5: 6}))
+		}},
+		{"Permutations", func(t *testing.T) { assertConforms(t, iter.Permutations(ints(3))) }},
+		{"PermutationsShared", func(t *testing.T) {
+			assertConforms(t, iter.IMap(slices.Clone, iter.PermutationsShared(ints(3))))
+		}},
+		{"PermutationsLen", func(t *testing.T) { assertConforms(t, iter.PermutationsLen(ints(3), 2)) }},
+		{"PermutationsLenShared", func(t *testing.T) {
+			assertConforms(t, iter.IMap(slices.Clone, iter.PermutationsLenShared(ints(3), 2)))
+		}},
+		{"Combinations", func(t *testing.T) { assertConforms(t, iter.Combinations(ints(4), 2)) }},
+		{"CombinationsWithReplacement", func(t *testing.T) {
+			assertConforms(t, iter.CombinationsWithReplacement(ints(3), 2))
+		}},
+		{"Product", func(t *testing.T) { assertConforms(t, iter.Product(ints(2), ints(3))) }},
+		{"IProduct", func(t *testing.T) { assertConforms(t, iter.IProduct(values(), values())) }},
+		{"Append", func(t *testing.T) { assertConforms(t, iter.Append(values(), values())) }},
+		{"Append2", func(t *testing.T) { assertConforms2(t, iter.Append2(values2(), values2())) }},
+		{"First", func(t *testing.T) { assertConforms(t, iter.First(values2())) }},
+		{"Second", func(t *testing.T) { assertConforms(t, iter.Second(values2())) }},
+		{"Filter2", func(t *testing.T) {
+			assertConforms2(t, iter.Filter2(func(k int, _ string) bool { return isOdd(k) }, values2()))
+		}},
+		{"MapPairs", func(t *testing.T) {
+			assertConforms2(t, iter.MapPairs(func(k int, v string) (string, int) { return v, k }, values2()))
+		}},
+		{"MapKeys", func(t *testing.T) { assertConforms2(t, iter.MapKeys(double, values2())) }},
+		{"MapValues", func(t *testing.T) {
+			assertConforms2(t, iter.MapValues(func(v string) string { return v + v }, values2()))
+		}},
+		{"TakeWhile2", func(t *testing.T) {
+			assertConforms2(t, iter.TakeWhile2(func(k int, _ string) bool { return isSmall(k) }, values2()))
+		}},
+		{"DropWhile2", func(t *testing.T) {
+			assertConforms2(t, iter.DropWhile2(func(k int, _ string) bool { return isSmall(k) }, values2()))
+		}},
+		{"Swap", func(t *testing.T) { assertConforms2(t, iter.Swap(values2())) }},
+		{"Take", func(t *testing.T) { assertConforms(t, iter.Take(values(), 3)) }},
+		{"Skip", func(t *testing.T) { assertConforms(t, iter.Skip(values(), 2)) }},
+		{"Take2", func(t *testing.T) { assertConforms2(t, iter.Take2(values2(), 3)) }},
+		{"Skip2", func(t *testing.T) { assertConforms2(t, iter.Skip2(values2(), 2)) }},
+		{"Slice", func(t *testing.T) { assertConforms(t, iter.Slice(iter.Count(0, 1), 1, 10, 2)) }},
+		{"Slice2", func(t *testing.T) { assertConforms2(t, iter.Slice2(values2(), 1, -1, 2)) }},
+		{"TakeLast", func(t *testing.T) { assertConforms(t, iter.TakeLast(values(), 3)) }},
+		{"TakeLast2", func(t *testing.T) { assertConforms2(t, iter.TakeLast2(values2(), 3)) }},
+		{"SkipLast", func(t *testing.T) { assertConforms(t, iter.SkipLast(values(), 2)) }},
+		{"SkipLast2", func(t *testing.T) { assertConforms2(t, iter.SkipLast2(values2(), 2)) }},
+		{"Enumerate", func(t *testing.T) { assertConforms2(t, iter.Enumerate(values())) }},
+		{"EnumerateFrom", func(t *testing.T) { assertConforms2(t, iter.EnumerateFrom(values(), 1)) }},
+		{"FilterIndexed", func(t *testing.T) {
+			assertConforms(t, iter.FilterIndexed(func(i, _ int) bool { return isOdd(i) }, values()))
+		}},
+		{"MapIndexed", func(t *testing.T) { assertConforms(t, iter.MapIndexed(add, values())) }},
+		{"TakeWhileIndexed", func(t *testing.T) {
+			assertConforms(t, iter.TakeWhileIndexed(func(i, _ int) bool { return isSmall(i) }, values()))
+		}},
+		{"DropWhileIndexed", func(t *testing.T) {
+			assertConforms(t, iter.DropWhileIndexed(func(i, _ int) bool { return isSmall(i) }, values()))
+		}},
+		{"Flatten", func(t *testing.T) {
+			assertConforms(t, iter.Flatten(iter.IMap(slices.Values, slices.Values([][]int{{1, 2}, {}, {3}}))))
+		}},
+		{"FlattenSlices", func(t *testing.T) {
+			assertConforms(t, iter.FlattenSlices(slices.Values([][]int{{1, 2}, {}, {3}})))
+		}},
+		{"FlatMap", func(t *testing.T) {
+			assertConforms(t, iter.FlatMap(func(i int) stdIter.Seq[int] { return iter.RepeatN(i, i) }, values()))
+		}},
+		{"Flatten2", func(t *testing.T) { assertConforms2(t, iter.Flatten2(iter.GroupBy(parity, ints(5)))) }},
+		{"Intersperse", func(t *testing.T) { assertConforms(t, iter.Intersperse(values(), 0)) }},
+		{"IntersperseFunc", func(t *testing.T) {
+			assertConforms(t, iter.IntersperseFunc(values(), func() int { return 0 }))
+		}},
+		{"Distinct", func(t *testing.T) { assertConforms(t, iter.Distinct(iter.ChainSeq(values(), values()))) }},
+		{"Chunk", func(t *testing.T) { assertConforms(t, iter.Chunk(values(), 2)) }},
+		{"Windows", func(t *testing.T) { assertConforms(t, iter.Windows(values(), 3, 2)) }},
+		{"WindowsShared", func(t *testing.T) {
+			assertConforms(t, iter.IMap(slices.Clone, iter.WindowsShared(values(), 3, 2)))
+		}},
+		{"Window", func(t *testing.T) { assertConforms(t, iter.Window(values(), 2)) }},
+		{"Tumbling", func(t *testing.T) { assertConforms(t, iter.Tumbling(values(), 2)) }},
+		{"Pairwise", func(t *testing.T) { assertConforms2(t, iter.Pairwise(values())) }},
+		{"Count", func(t *testing.T) { assertConforms(t, iter.From(iter.Count(1000, 5)).Take(5).Seq()) }},
+		{"Cycle", func(t *testing.T) { assertConforms(t, iter.From(iter.Cycle(slices.Values(ints(3)))).Take(7).Seq()) }},
+		{"Repeat", func(t *testing.T) { assertConforms(t, iter.From(iter.Repeat(1)).Take(5).Seq()) }},
+		{"RepeatN", func(t *testing.T) { assertConforms(t, iter.RepeatN(1, 5)) }},
+		{"Iterate", func(t *testing.T) { assertConforms(t, iter.From(iter.Iterate(1, double)).Take(5).Seq()) }},
+		{"Unfold", func(t *testing.T) {
+			assertConforms(t, iter.Unfold(5, func(n int) (int, int, bool) { return n, n - 1, n > 0 }))
+		}},
+		{"Of", func(t *testing.T) { assertConforms(t, iter.Of(1, 2, 3).Seq()) }},
+		{"From", func(t *testing.T) { assertConforms(t, iter.From(values()).Seq()) }},
+		{"Stream.Filter", func(t *testing.T) { assertConforms(t, iter.From(values()).Filter(isOdd).Seq()) }},
+		{"Stream.FilterFalse", func(t *testing.T) {
+			assertConforms(t, iter.From(values()).FilterFalse(isOdd).Seq())
+		}},
+		{"Stream.TakeWhile", func(t *testing.T) {
+			assertConforms(t, iter.From(values()).TakeWhile(isSmall).Seq())
+		}},
+		{"Stream.DropWhile", func(t *testing.T) {
+			assertConforms(t, iter.From(values()).DropWhile(isSmall).Seq())
+		}},
+		{"Stream.Take", func(t *testing.T) { assertConforms(t, iter.From(values()).Take(2).Seq()) }},
+		{"Stream.Skip", func(t *testing.T) { assertConforms(t, iter.From(values()).Skip(2).Seq()) }},
+		{"Stream.Slice", func(t *testing.T) { assertConforms(t, iter.From(values()).Slice(1, 4, 1).Seq()) }},
+		{"Stream.TakeLast", func(t *testing.T) { assertConforms(t, iter.From(values()).TakeLast(2).Seq()) }},
+		{"Stream.SkipLast", func(t *testing.T) { assertConforms(t, iter.From(values()).SkipLast(2).Seq()) }},
+		{"Stream.Peek", func(t *testing.T) { assertConforms(t, iter.From(values()).Peek(func(int) {}).Seq()) }},
+		{"Stream.SortedFunc", func(t *testing.T) {
+			assertConforms(t, iter.Of(3, 1, 2).SortedFunc(cmp.Compare[int]).Seq())
+		}},
+		{"WithContext", func(t *testing.T) { assertConforms(t, iter.WithContext(t.Context(), values())) }},
+		{"WithContext2", func(t *testing.T) { assertConforms2(t, iter.WithContext2(t.Context(), values2())) }},
+		{"WithContextErr", func(t *testing.T) { assertConforms2(t, iter.WithContextErr(t.Context(), values())) }},
+		{"Batch", func(t *testing.T) { assertConforms2(t, iter.Batch(t.Context(), values(), 2, time.Hour)) }},
+		{"NoError", func(t *testing.T) { assertConforms2(t, iter.NoError(values())) }},
+		{"SkipErrors", func(t *testing.T) { assertConforms(t, iter.SkipErrors(fallible(1, -1, 2))) }},
+		{"Must", func(t *testing.T) { assertConforms(t, iter.Must(fallible(1, 2))) }},
+		{"TryMap", func(t *testing.T) { assertConforms2(t, iter.TryMap(tryDouble, fallible(1, -1, 2))) }},
+		{"TryFilter", func(t *testing.T) { assertConforms2(t, iter.TryFilter(tryIsOdd, fallible(1, -1, 2))) }},
+		{"ParallelMap", func(t *testing.T) {
+			assertConforms2(t, iter.ParallelMap(t.Context(), 2, parallelDouble, values()))
+		}},
+		{"ParallelMapUnordered", func(t *testing.T) {
+			assertConformsUnordered(t, iter.First(iter.ParallelMapUnordered(t.Context(), 2, 1, parallelDouble, values())))
+		}},
+		{"ParallelFilterUnordered", func(t *testing.T) {
+			assertConformsUnordered(t, iter.First(iter.ParallelFilterUnordered(t.Context(), 2, 1, parallelIsOdd, values())))
+		}},
+	}
+}
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range conformanceCases() {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.test(t)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package iter
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// WithContext returns a sequence of elements from the input sequence.
+// The resulting sequence stops as soon as the context is done.
+// The context is checked before each element is yielded, it does not
+// interrupt an input sequence blocked while producing an element.
+// The reason of the cancellation is given by context.Cause.
+func WithContext[T any](ctx context.Context, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		for elem := range seq {
+			if ctx.Err() != nil || !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// WithContext2 returns a sequence of pairs of elements from the input
+// sequence.
+// The resulting sequence stops as soon as the context is done.
+// The context is checked before each pair is yielded, it does not interrupt
+// an input sequence blocked while producing a pair.
+// The reason of the cancellation is given by context.Cause.
+func WithContext2[T, U any](ctx context.Context, seq iter.Seq2[T, U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		for elem1, elem2 := range seq {
+			if ctx.Err() != nil || !yield(elem1, elem2) {
+				return
+			}
+		}
+	}
+}
+
+// WithContextErr returns a sequence of elements from the input sequence,
+// paired with a nil error.
+// When the context is done, the resulting sequence yields a last pair holding
+// the zero value and the cause of the cancellation, as returned by
+// context.Cause, then stops.
+func WithContextErr[T any](ctx context.Context, seq iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		if ctx.Err() != nil {
+			yield(zero, context.Cause(ctx))
+
+			return
+		}
+
+		for elem := range seq {
+			if ctx.Err() != nil {
+				yield(zero, context.Cause(ctx))
+
+				return
+			}
+
+			if !yield(elem, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesContext returns a slice of elements from the input sequence.
+// If the context is done before the input sequence is exhausted, it returns
+// the elements collected so far and ctx.Err().
+func ValuesContext[T any](ctx context.Context, seq iter.Seq[T]) ([]T, error) {
+	res := Values(WithContext(ctx, seq))
+
+	return res, ctx.Err()
+}
+
+// LenContext returns the number of elements in the input sequence.
+// If the context is done before the input sequence is exhausted, it returns
+// the number of elements counted so far and ctx.Err().
+func LenContext[T any](ctx context.Context, seq iter.Seq[T]) (int, error) {
+	l := Len(WithContext(ctx, seq))
+
+	return l, ctx.Err()
+}
+
+// EqualContext reports whether the input sequences are equal, as Equal does.
+// If the context is done before the comparison is over, it returns false and
+// ctx.Err().
+func EqualContext[T comparable](ctx context.Context, seqA, seqB iter.Seq[T]) (bool, error) {
+	equal := Equal(WithContext(ctx, seqA), WithContext(ctx, seqB))
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return equal, nil
+}
+
+// Batch returns a fallible sequence of batches of elements from the input
+// sequence.
+// A batch is yielded as soon as it has maxSize elements, or when maxWait has
+// elapsed since its first element was received, whichever comes first.
+// The last batch is yielded when the input sequence is exhausted.
+// Each batch is a new slice, paired with a nil error.
+// The input sequence is ranged over in a separate goroutine, which is not
+// waited for, so that an input sequence waiting for its next element does not
+// block the iteration: it returns once the input sequence yields again, or
+// returns.
+// When ctx is done, the pending batch is yielded, then the resulting sequence
+// yields a last pair holding a nil batch and the cause of the cancellation, as
+// returned by context.Cause, and stops.
+// If the input sequence panics, the panic is propagated to the goroutine
+// ranging over the resulting sequence.
+// It panics if maxSize is less than 1, or if maxWait is not positive.
+func Batch[T any](ctx context.Context, seq iter.Seq[T], maxSize int, maxWait time.Duration) iter.Seq2[[]T, error] {
+	if maxSize < 1 {
+		panic("iter: maxSize cannot be less than 1")
+	}
+
+	if maxWait <= 0 {
+		panic("iter: maxWait must be positive")
+	}
+
+	return func(yield func([]T, error) bool) {
+		if ctx.Err() != nil {
+			yield(nil, context.Cause(ctx))
+
+			return
+		}
+
+		workCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			elems    = make(chan T)
+			panicked bool
+			panicVal any
+		)
+
+		go func() {
+			defer close(elems)
+			defer func() {
+				if r := recover(); r != nil {
+					panicked, panicVal = true, r
+				}
+			}()
+
+			for elem := range seq {
+				select {
+				case elems <- elem:
+				case <-workCtx.Done():
+					return
+				}
+			}
+		}()
+
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+
+		defer timer.Stop()
+
+		var (
+			timeout <-chan time.Time
+			batch   []T
+		)
+
+		flush := func() bool {
+			timer.Stop()
+
+			timeout = nil
+			res := batch
+			batch = nil
+
+			return yield(res, nil)
+		}
+
+		for {
+			select {
+			case elem, ok := <-elems:
+				if !ok {
+					if panicked {
+						panic(panicVal)
+					}
+
+					if len(batch) > 0 && !flush() {
+						return
+					}
+
+					if ctx.Err() != nil {
+						yield(nil, context.Cause(ctx))
+					}
+
+					return
+				}
+
+				batch = append(batch, elem)
+				if len(batch) == 1 {
+					timer.Reset(maxWait)
+
+					timeout = timer.C
+				}
+
+				if len(batch) == maxSize && !flush() {
+					return
+				}
+
+			case <-timeout:
+				if !flush() {
+					return
+				}
+
+			case <-workCtx.Done():
+				if len(batch) > 0 && !flush() {
+					return
+				}
+
+				yield(nil, context.Cause(ctx))
+
+				return
+			}
+		}
+	}
+}
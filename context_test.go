@@ -0,0 +1,367 @@
+package iter_test
+
+import (
+	"context"
+	"errors"
+	stdIter "iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommoulard/iter"
+)
+
+var errCancelCause = errors.New("cancel cause")
+
+// cancelAfter returns a sequence of the given elements that cancels the
+// context after yielding n elements.
+func cancelAfter[T any](cancel context.CancelCauseFunc, n int, elems ...T) stdIter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, elem := range elems {
+			if i == n {
+				cancel(errCancelCause)
+			}
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		n      int
+		elems  []int
+		expect []int
+	}{
+		{
+			name:   "not cancelled",
+			n:      42,
+			elems:  []int{1, 2, 3},
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "cancelled during iteration",
+			n:      2,
+			elems:  []int{1, 2, 3},
+			expect: []int{1, 2},
+		},
+		{
+			name:   "cancelled before first element",
+			n:      0,
+			elems:  []int{1, 2, 3},
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancelCause(t.Context())
+			defer cancel(nil)
+
+			got := iter.Values(iter.WithContext(ctx, cancelAfter(cancel, test.n, test.elems...)))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+
+	t.Run("already cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		called := false
+		seq := func(func(int) bool) { called = true }
+
+		assert.Empty(t, iter.Values(iter.WithContext(ctx, seq)))
+		assert.False(t, called)
+	})
+}
+
+func TestWithContext2(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	seq := iter.IZip(cancelAfter(cancel, 2, 1, 2, 3), slices.Values([]string{"a", "b", "c"}))
+
+	gotA, gotB := iter.Values2(iter.WithContext2(ctx, seq))
+	assert.Equal(t, []int{1, 2}, gotA)
+	assert.Equal(t, []string{"a", "b"}, gotB)
+	assert.ErrorIs(t, context.Cause(ctx), errCancelCause)
+}
+
+func TestWithContextErr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		gotA, gotB := iter.Values2(iter.WithContextErr(t.Context(), slices.Values([]int{1, 2})))
+		assert.Equal(t, []int{1, 2}, gotA)
+		assert.Equal(t, []error{nil, nil}, gotB)
+	})
+
+	t.Run("cancelled during iteration", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancelCause(t.Context())
+		defer cancel(nil)
+
+		gotA, gotB := iter.Values2(iter.WithContextErr(ctx, cancelAfter(cancel, 1, 1, 2, 3)))
+		assert.Equal(t, []int{1, 0}, gotA)
+		require.Len(t, gotB, 2)
+		require.NoError(t, gotB[0])
+		assert.ErrorIs(t, gotB[1], errCancelCause)
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		gotA, gotB := iter.Values2(iter.WithContextErr(ctx, slices.Values([]int{1, 2})))
+		assert.Equal(t, []int{0}, gotA)
+		require.Len(t, gotB, 1)
+		assert.ErrorIs(t, gotB[0], context.Canceled)
+	})
+}
+
+func TestValuesContext(t *testing.T) {
+	t.Parallel()
+
+	got, err := iter.ValuesContext(t.Context(), slices.Values([]int{1, 2, 3}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	got, err = iter.ValuesContext(ctx, cancelAfter(cancel, 2, 1, 2, 3))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestLenContext(t *testing.T) {
+	t.Parallel()
+
+	got, err := iter.LenContext(t.Context(), slices.Values([]int{1, 2, 3}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, got)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	got, err = iter.LenContext(ctx, cancelAfter(cancel, 1, 1, 2, 3))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, got)
+}
+
+func TestEqualContext(t *testing.T) {
+	t.Parallel()
+
+	got, err := iter.EqualContext(t.Context(), slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 2, 3}))
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = iter.EqualContext(t.Context(), slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 5, 3}))
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	got, err = iter.EqualContext(ctx, cancelAfter(cancel, 1, 1, 2, 3), slices.Values([]int{1, 2, 3}))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.False(t, got)
+}
+
+// chanSeq returns a sequence of the elements received from the channel.
+func chanSeq[T any](ch <-chan T) stdIter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range ch {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	tests := []struct {
+		name    string
+		a       []int
+		maxSize int
+		expect  [][]int
+	}{
+		{
+			name:    "batches with a shorter last batch",
+			a:       ints(5),
+			maxSize: 2,
+			expect:  [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:    "batches of exact size",
+			a:       ints(4),
+			maxSize: 2,
+			expect:  [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:    "empty sequence",
+			a:       nil,
+			maxSize: 2,
+			expect:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := iter.TryValues(iter.Batch(t.Context(), slices.Values(test.a), test.maxSize, time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestBatch_maxWait(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ch := make(chan int)
+
+	go func() {
+		defer close(ch)
+
+		ch <- 1
+		ch <- 2
+		time.Sleep(100 * time.Millisecond)
+		ch <- 3
+		ch <- 4
+		ch <- 5
+		ch <- 6
+	}()
+
+	got, err := iter.TryValues(iter.Batch(t.Context(), chanSeq(ch), 3, 20*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4, 5}, {6}}, got)
+}
+
+func TestBatch_cancelled(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	ch := make(chan int)
+	// Closed before checking for leaks, so that the goroutine ranging over the
+	// source returns.
+	defer close(ch)
+
+	// The source cancels ctx once its first element is received, then waits
+	// for an element that never comes.
+	source := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+
+		cancel(errCancelCause)
+
+		chanSeq(ch)(yield)
+	}
+
+	var (
+		got  [][]int
+		errs []error
+	)
+
+	for batch, err := range iter.Batch(ctx, source, 2, time.Hour) {
+		got = append(got, batch)
+		errs = append(errs, err)
+	}
+
+	// The pending batch is yielded before the cause.
+	assert.Equal(t, [][]int{{1}, nil}, got)
+	assert.Equal(t, []error{nil, errCancelCause}, errs)
+}
+
+func TestBatch_alreadyCancelled(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	cancel(errCancelCause)
+
+	got, err := iter.TryValues(iter.Batch(ctx, slices.Values(ints(3)), 2, time.Hour))
+	require.ErrorIs(t, err, errCancelCause)
+	assert.Empty(t, got)
+}
+
+func TestBatch_break(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	source := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	for batch, err := range iter.Batch(t.Context(), source, 3, time.Hour) {
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, batch)
+
+		break
+	}
+}
+
+func TestBatch_breakIdleSource(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	// Closed before checking for leaks, so that the goroutine ranging over the
+	// source returns.
+	defer close(ch)
+
+	for batch, err := range iter.Batch(t.Context(), chanSeq(ch), 2, time.Hour) {
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, batch)
+
+		break
+	}
+}
+
+func TestBatch_sourcePanic(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	source := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+
+		panic("source")
+	}
+
+	assert.PanicsWithValue(t, "source", func() {
+		_, _ = iter.TryValues(iter.Batch(t.Context(), source, 3, time.Hour))
+	})
+}
+
+func TestBatch_invalidArguments(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { iter.Batch(t.Context(), slices.Values(ints(1)), 0, time.Second) })
+	assert.Panics(t, func() { iter.Batch(t.Context(), slices.Values(ints(1)), 1, 0) })
+}
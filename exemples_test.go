@@ -1,8 +1,15 @@
 package iter_test
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	stdIter "iter"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tommoulard/iter"
 )
@@ -54,14 +61,168 @@ func ExampleIZip() {
 }
 
 func ExampleZipLongest() {
-	for a, b := range iter.ZipLongest([]int{1, 2, 3}, []int{4}, 0) {
+	for a, b := range iter.ZipLongest([]string{"a", "b", "c"}, []int{4}, "-", 0) {
 		fmt.Println(a, b)
 	}
 
 	// Output:
-	// 1 4
-	// 2 0
-	// 3 0
+	// a 4
+	// b 0
+	// c 0
+}
+
+func ExampleIZipLongest() {
+	for a, b := range iter.IZipLongest(slices.Values([]string{"a"}), slices.Values([]int{4, 5, 6}), "-", 0) {
+		fmt.Println(a, b)
+	}
+
+	// Output:
+	// a 4
+	// - 5
+	// - 6
+}
+
+func ExampleZipLongestN() {
+	for elems := range iter.ZipLongestN(0, []int{1, 2, 3}, []int{4}, []int{5, 6}) {
+		fmt.Println(elems)
+	}
+
+	// Output:
+	// [1 4 5]
+	// [2 0 6]
+	// [3 0 0]
+}
+
+func ExampleIZipLongestN() {
+	for elems := range iter.IZipLongestN(0, slices.Values([]int{1, 2, 3}), slices.Values([]int{4})) {
+		fmt.Println(elems)
+	}
+
+	// Output:
+	// [1 4]
+	// [2 0]
+	// [3 0]
+}
+
+func ExampleZip3() {
+	for t := range iter.Zip3([]string{"a", "b"}, []int{1, 2}, []bool{true, false}) {
+		fmt.Println(t.A, t.B, t.C)
+	}
+
+	// Output:
+	// a 1 true
+	// b 2 false
+}
+
+func ExampleIZip3() {
+	for t := range iter.IZip3(slices.Values([]string{"a", "b"}), slices.Values([]int{1, 2, 3}), slices.Values([]bool{true, false})) {
+		fmt.Println(t.A, t.B, t.C)
+	}
+
+	// Output:
+	// a 1 true
+	// b 2 false
+}
+
+func ExampleZip4() {
+	for t := range iter.Zip4([]string{"a", "b"}, []int{1, 2}, []bool{true, false}, []float64{0.5, 1.5}) {
+		fmt.Println(t.A, t.B, t.C, t.D)
+	}
+
+	// Output:
+	// a 1 true 0.5
+	// b 2 false 1.5
+}
+
+func ExampleIZip4() {
+	for t := range iter.IZip4(
+		slices.Values([]string{"a", "b"}),
+		slices.Values([]int{1, 2}),
+		slices.Values([]bool{true, false}),
+		slices.Values([]float64{0.5}),
+	) {
+		fmt.Println(t.A, t.B, t.C, t.D)
+	}
+
+	// Output:
+	// a 1 true 0.5
+}
+
+func ExampleZipLongest3() {
+	for t := range iter.ZipLongest3([]string{"a", "b"}, []int{1}, []bool{true}, "-", 0, false) {
+		fmt.Println(t.A, t.B, t.C)
+	}
+
+	// Output:
+	// a 1 true
+	// b 0 false
+}
+
+func ExampleIZipLongest3() {
+	for t := range iter.IZipLongest3(slices.Values([]string{"a"}), slices.Values([]int{1, 2}), slices.Values([]bool{}), "-", 0, false) {
+		fmt.Println(t.A, t.B, t.C)
+	}
+
+	// Output:
+	// a 1 false
+	// - 2 false
+}
+
+func ExampleZipLongest4() {
+	for t := range iter.ZipLongest4([]string{"a", "b"}, []int{1}, []bool{true}, []float64{}, "-", 0, false, -1) {
+		fmt.Println(t.A, t.B, t.C, t.D)
+	}
+
+	// Output:
+	// a 1 true -1
+	// b 0 false -1
+}
+
+func ExampleIZipLongest4() {
+	for t := range iter.IZipLongest4(
+		slices.Values([]string{"a"}),
+		slices.Values([]int{1, 2}),
+		slices.Values([]bool{true}),
+		slices.Values([]float64{0.5}),
+		"-", 0, false, -1,
+	) {
+		fmt.Println(t.A, t.B, t.C, t.D)
+	}
+
+	// Output:
+	// a 1 true 0.5
+	// - 2 false -1
+}
+
+func ExampleZipN() {
+	for elems := range iter.ZipN([]int{1, 2, 3}, []int{4, 5}, []int{6, 7, 8}) {
+		fmt.Println(elems)
+	}
+
+	// Output:
+	// [1 4 6]
+	// [2 5 7]
+}
+
+func ExampleIZipN() {
+	for elems := range iter.IZipN(slices.Values([]int{1, 2, 3}), slices.Values([]int{4, 5}), slices.Values([]int{6, 7, 8})) {
+		fmt.Println(elems)
+	}
+
+	// Output:
+	// [1 4 6]
+	// [2 5 7]
+}
+
+func ExampleUnzip() {
+	names, ages := iter.Unzip(iter.Zip([]string{"alice", "bob"}, []int{31, 42}))
+
+	fmt.Println(iter.Values(names))
+	fmt.Println(iter.Values(ages))
+
+	// Output:
+	// [alice bob]
+	// [31 42]
 }
 
 func ExampleAccumulate() {
@@ -77,6 +238,32 @@ func ExampleAccumulate() {
 	// 15
 }
 
+func ExampleIAccumulate() {
+	for a := range iter.IAccumulate(slices.Values([]int{1, 2, 3, 4, 5})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 3
+	// 6
+	// 10
+	// 15
+}
+
+func ExampleAccumulateFunc() {
+	for a := range iter.AccumulateFunc(func(a, b int) int { return max(a, b) }, 0, slices.Values([]int{3, 1, 4, 1, 5})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 3
+	// 3
+	// 4
+	// 4
+	// 5
+}
+
 func ExampleChain() {
 	for a := range iter.Chain([]int{1, 2, 3}, []int{4, 5, 6}) {
 		fmt.Println(a)
@@ -115,6 +302,16 @@ func ExampleCompress() {
 	// 3
 }
 
+func ExampleICompress() {
+	for a := range iter.ICompress(slices.Values([]int{1, 2, 3}), slices.Values([]bool{true, false, true})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 3
+}
+
 func ExampleDropWhile() {
 	for a := range iter.DropWhile(func(x int) bool { return x < 5 }, []int{1, 4, 6, 3, 8}) {
 		fmt.Println(a)
@@ -126,6 +323,17 @@ func ExampleDropWhile() {
 	// 8
 }
 
+func ExampleIDropWhile() {
+	for a := range iter.IDropWhile(func(x int) bool { return x < 5 }, slices.Values([]int{1, 4, 6, 3, 8})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 6
+	// 3
+	// 8
+}
+
 func ExampleFilter() {
 	for a := range iter.Filter(func(x int) bool { return x%2 == 0 }, []int{1, 2, 3, 4, 5}) {
 		fmt.Println(a)
@@ -136,6 +344,16 @@ func ExampleFilter() {
 	// 4
 }
 
+func ExampleIFilter() {
+	for a := range iter.IFilter(func(x int) bool { return x%2 == 0 }, slices.Values([]int{1, 2, 3, 4, 5})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 2
+	// 4
+}
+
 func ExampleFilterFalse() {
 	for a := range iter.FilterFalse(func(x int) bool { return x%2 == 0 }, []int{1, 2, 3, 4, 5}) {
 		fmt.Println(a)
@@ -147,18 +365,99 @@ func ExampleFilterFalse() {
 	// 5
 }
 
+func ExampleIFilterFalse() {
+	for a := range iter.IFilterFalse(func(x int) bool { return x%2 == 0 }, slices.Values([]int{1, 2, 3, 4, 5})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 3
+	// 5
+}
+
 func ExampleGroupBy() {
-	out := make(map[int][]int)
-	for k, v := range iter.GroupBy(func(x int) int { return x % 2 }, []int{1, 2, 3, 4, 5}) {
-		out[k] = iter.Values(v)
+	for k, v := range iter.GroupBy(func(x int) int { return x % 2 }, []int{1, 3, 2, 4, 5}) {
+		fmt.Println(k, iter.Values(v))
 	}
 
-	fmt.Println(out[0])
-	fmt.Println(out[1])
+	// Output:
+	// 1 [1 3]
+	// 0 [2 4]
+	// 1 [5]
+}
+
+func ExampleIGroupBy() {
+	for k, v := range iter.IGroupBy(func(x int) int { return x % 2 }, slices.Values([]int{1, 3, 2, 4, 5})) {
+		fmt.Println(k, iter.Values(v))
+	}
 
 	// Output:
-	// [2 4]
-	// [1 3 5]
+	// 1 [1 3]
+	// 0 [2 4]
+	// 1 [5]
+}
+
+func ExampleGroupByStable() {
+	for k, v := range iter.GroupByStable(func(x int) int { return x % 2 }, []int{1, 3, 2, 4, 5}) {
+		fmt.Println(k, iter.Values(v))
+	}
+
+	// Output:
+	// 1 [1 3 5]
+	// 0 [2 4]
+}
+
+func ExampleIGroupByStable() {
+	for k, v := range iter.IGroupByStable(func(x int) int { return x % 2 }, slices.Values([]int{2, 1, 3, 4, 5})) {
+		fmt.Println(k, iter.Values(v))
+	}
+
+	// Output:
+	// 0 [2 4]
+	// 1 [1 3 5]
+}
+
+func ExampleCountBy() {
+	counts := iter.CountBy(func(s string) int { return len(s) }, slices.Values([]string{"a", "bb", "cc", "d", "eee"}))
+
+	fmt.Println(counts)
+
+	// Output:
+	// map[1:2 2:2 3:1]
+}
+
+func ExampleSumBy() {
+	sums := iter.SumBy(
+		func(s string) bool { return len(s)%2 == 0 },
+		func(s string) int { return len(s) },
+		slices.Values([]string{"a", "bb", "ccc", "dddd", "eeeee"}),
+	)
+
+	fmt.Println(sums)
+
+	// Output:
+	// map[false:9 true:6]
+}
+
+func ExampleAggregateBy() {
+	longest := iter.AggregateBy(
+		func(s string) byte { return s[0] },
+		func(acc, s string) string {
+			if len(s) > len(acc) {
+				return s
+			}
+
+			return acc
+		},
+		"",
+		slices.Values([]string{"apple", "banana", "avocado", "blueberry", "cherry"}),
+	)
+
+	fmt.Println(longest['a'], longest['b'], longest['c'])
+
+	// Output:
+	// avocado blueberry cherry
 }
 
 func ExampleMap() {
@@ -223,6 +522,16 @@ func ExampleTakeWhile() {
 	// 4
 }
 
+func ExampleITakeWhile() {
+	for a := range iter.ITakeWhile(func(x int) bool { return x < 5 }, slices.Values([]int{1, 4, 6, 3, 8})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 4
+}
+
 func ExampleChainMap() {
 	m := map[int]string{
 		int('b'): "b",
@@ -246,10 +555,10 @@ func ExamplePermutations() {
 
 	// Output:
 	// [1 2 3]
-	// [2 1 3]
-	// [3 1 2]
 	// [1 3 2]
+	// [2 1 3]
 	// [2 3 1]
+	// [3 1 2]
 	// [3 2 1]
 }
 
@@ -260,11 +569,79 @@ func ExamplePermutationsLen() {
 
 	// Output:
 	// [1 2]
+	// [1 3]
 	// [2 1]
+	// [2 3]
 	// [3 1]
+	// [3 2]
+}
+
+func ExamplePermutationsLenShared() {
+	var count int
+
+	// The yielded slice is overwritten by the next permutation.
+	for value := range iter.PermutationsLenShared([]int{1, 2, 3, 4}, 3) {
+		if value[0] < value[1] && value[1] < value[2] {
+			count++
+		}
+	}
+
+	fmt.Println(count)
+
+	// Output:
+	// 4
+}
+
+func ExampleCombinations() {
+	for value := range iter.Combinations([]string{"a", "b", "c", "d"}, 2) {
+		fmt.Println(value)
+	}
+
+	// Output:
+	// [a b]
+	// [a c]
+	// [a d]
+	// [b c]
+	// [b d]
+	// [c d]
+}
+
+func ExampleCombinationsWithReplacement() {
+	for value := range iter.CombinationsWithReplacement([]string{"a", "b", "c"}, 2) {
+		fmt.Println(value)
+	}
+
+	// Output:
+	// [a a]
+	// [a b]
+	// [a c]
+	// [b b]
+	// [b c]
+	// [c c]
+}
+
+func ExampleProduct() {
+	for value := range iter.Product([]string{"linux", "darwin"}, []string{"amd64", "arm64"}) {
+		fmt.Println(value)
+	}
+
+	// Output:
+	// [linux amd64]
+	// [linux arm64]
+	// [darwin amd64]
+	// [darwin arm64]
+}
+
+func ExampleIProduct() {
+	for value := range iter.IProduct(slices.Values([]int{1, 2}), slices.Values([]int{3, 4})) {
+		fmt.Println(value)
+	}
+
+	// Output:
 	// [1 3]
+	// [1 4]
 	// [2 3]
-	// [3 2]
+	// [2 4]
 }
 
 func ExampleAppend() {
@@ -327,23 +704,720 @@ func ExampleSecond() {
 	// "6"
 }
 
-func ExampleEqual() {
-	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 3}), iter.Chain([]int{1, 2, 3}))) // true
-	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 3}), iter.Chain([]int{1, 2})))    // true
-	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 5}), iter.Chain([]int{1, 2, 3}))) // false
-	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 5}), iter.Chain([]int{1, 2})))    // true
+func ExampleFilter2() {
+	i := iter.ChainMap(map[int]string{1: "a", 2: "b", 3: "c"})
+
+	for key, value := range iter.Filter2(func(k int, _ string) bool { return k != 2 }, i) {
+		fmt.Println(key, value)
+	}
 
 	// Output:
-	// true
-	// true
-	// false
-	// true
+	// 1 a
+	// 3 c
 }
 
-func ExampleLen() {
-	intSeq := slices.Values([]int{1, 2, 3})
-	fmt.Println(iter.Len(intSeq))
+func ExampleMapPairs() {
+	i := iter.ChainMap(map[int]string{1: "a", 2: "b", 3: "c"})
+
+	for key, value := range iter.MapPairs(func(k int, v string) (string, int) { return v, k * k }, i) {
+		fmt.Println(key, value)
+	}
 
 	// Output:
-	// 3
+	// a 1
+	// b 4
+	// c 9
+}
+
+func ExampleMapKeys() {
+	i := iter.ChainMap(map[int]string{1: "a", 2: "b", 3: "c"})
+
+	for key, value := range iter.MapKeys(func(k int) int { return k * 10 }, i) {
+		fmt.Println(key, value)
+	}
+
+	// Output:
+	// 10 a
+	// 20 b
+	// 30 c
+}
+
+func ExampleMapValues() {
+	i := iter.ChainMap(map[int]string{1: "a", 2: "b", 3: "c"})
+
+	for key, value := range iter.MapValues(func(v string) string { return v + v }, i) {
+		fmt.Println(key, value)
+	}
+
+	// Output:
+	// 1 aa
+	// 2 bb
+	// 3 cc
+}
+
+func ExampleTakeWhile2() {
+	i := iter.Zip([]int{1, 2, 3, 4}, []int{4, 3, 2, 1})
+
+	for a, b := range iter.TakeWhile2(func(a, b int) bool { return a < b }, i) {
+		fmt.Println(a, b)
+	}
+
+	// Output:
+	// 1 4
+	// 2 3
+}
+
+func ExampleDropWhile2() {
+	i := iter.Zip([]int{1, 2, 3, 4}, []int{4, 3, 2, 1})
+
+	for a, b := range iter.DropWhile2(func(a, b int) bool { return a < b }, i) {
+		fmt.Println(a, b)
+	}
+
+	// Output:
+	// 3 2
+	// 4 1
+}
+
+func ExampleSwap() {
+	i := iter.ChainMap(map[int]string{1: "a", 2: "b", 3: "c"})
+
+	for key, value := range iter.Swap(i) {
+		fmt.Println(key, value)
+	}
+
+	// Output:
+	// a 1
+	// b 2
+	// c 3
+}
+
+func ExampleTake() {
+	for a := range iter.Take(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleSkip() {
+	for a := range iter.Skip(slices.Values([]int{1, 2, 3, 4, 5}), 3) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 4
+	// 5
+}
+
+func ExampleDistinct() {
+	for a := range iter.Distinct(slices.Values([]int{1, 2, 1, 3, 2})) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleChunk() {
+	for chunk := range iter.Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		fmt.Println(chunk)
+	}
+
+	// Output:
+	// [1 2]
+	// [3 4]
+	// [5]
+}
+
+func ExampleWindows() {
+	for window := range iter.Windows(slices.Values([]int{1, 2, 3, 4, 5, 6}), 3, 2) {
+		fmt.Println(window)
+	}
+
+	// Output:
+	// [1 2 3]
+	// [3 4 5]
+}
+
+func ExampleWindowsShared() {
+	// Moving average, without allocating a slice per window.
+	for window := range iter.WindowsShared(slices.Values([]float64{1, 2, 3, 4, 5}), 3, 1) {
+		var sum float64
+		for _, x := range window {
+			sum += x
+		}
+
+		fmt.Println(sum / float64(len(window)))
+	}
+
+	// Output:
+	// 2
+	// 3
+	// 4
+}
+
+func ExampleWindow() {
+	for window := range iter.Window(slices.Values([]int{1, 2, 3, 4}), 2) {
+		fmt.Println(window)
+	}
+
+	// Output:
+	// [1 2]
+	// [2 3]
+	// [3 4]
+}
+
+func ExampleTumbling() {
+	for window := range iter.Tumbling(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		fmt.Println(window)
+	}
+
+	// Output:
+	// [1 2]
+	// [3 4]
+}
+
+func ExamplePairwise() {
+	for a, b := range iter.Pairwise(slices.Values([]int{1, 2, 3})) {
+		fmt.Println(a, b)
+	}
+
+	// Output:
+	// 1 2
+	// 2 3
+}
+
+func ExampleReduce() {
+	fmt.Println(iter.Reduce(func(a, b int) int { return a * b }, slices.Values([]int{1, 2, 3, 4})))
+
+	// Output:
+	// 24 true
+}
+
+func ExampleFold() {
+	fmt.Println(iter.Fold(func(acc string, x int) string { return acc + strconv.Itoa(x) }, "0x", slices.Values([]int{1, 2, 3})))
+
+	// Output:
+	// 0x123
+}
+
+func ExampleEqual() {
+	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 3}), iter.Chain([]int{1, 2, 3}))) // true
+	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 3}), iter.Chain([]int{1, 2})))    // false
+	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 5}), iter.Chain([]int{1, 2, 3}))) // false
+	fmt.Println(iter.Equal(iter.Chain([]int{1, 2, 5}), iter.Chain([]int{1, 2})))    // false
+
+	// Output:
+	// true
+	// false
+	// false
+	// false
+}
+
+func ExampleEqualFunc() {
+	eq := func(i int, s string) bool { return strconv.Itoa(i) == s }
+
+	fmt.Println(iter.EqualFunc(slices.Values([]int{1, 2}), slices.Values([]string{"1", "2"}), eq))
+	fmt.Println(iter.EqualFunc(slices.Values([]int{1, 2}), slices.Values([]string{"1", "3"}), eq))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleEqual2() {
+	a := iter.Zip([]string{"a", "b"}, []int{1, 2})
+	b := iter.Zip([]string{"a", "b"}, []int{1, 3})
+
+	fmt.Println(iter.Equal2(a, a))
+	fmt.Println(iter.Equal2(a, b))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCompare() {
+	fmt.Println(iter.Compare(slices.Values([]int{1, 2}), slices.Values([]int{1, 2})))
+	fmt.Println(iter.Compare(slices.Values([]int{1, 2}), slices.Values([]int{1, 2, 3})))
+	fmt.Println(iter.Compare(slices.Values([]int{1, 3}), slices.Values([]int{1, 2, 3})))
+
+	// Output:
+	// 0
+	// -1
+	// 1
+}
+
+func ExampleCompareFunc() {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+
+	fmt.Println(iter.CompareFunc(slices.Values([]string{"a", "bb"}), slices.Values([]string{"c", "dd"}), byLen))
+	fmt.Println(iter.CompareFunc(slices.Values([]string{"a", "b"}), slices.Values([]string{"c", "dd"}), byLen))
+
+	// Output:
+	// 0
+	// -1
+}
+
+func ExampleLen() {
+	intSeq := slices.Values([]int{1, 2, 3})
+	fmt.Println(iter.Len(intSeq))
+
+	// Output:
+	// 3
+}
+
+func ExampleStream() {
+	s := iter.Of(5, 3, 8, 1, 6, 9).
+		Filter(func(x int) bool { return x > 2 }).
+		SortedFunc(cmp.Compare[int]).
+		Take(3)
+
+	fmt.Println(s.Collect())
+	fmt.Println(iter.Len(s.Seq()))
+	fmt.Println(iter.From(iter.IMap(strconv.Itoa, s.Seq())).Collect())
+
+	// Output:
+	// [3 5 6]
+	// 3
+	// [3 5 6]
+}
+
+func ExampleStream_Reduce() {
+	fmt.Println(iter.Of(1, 2, 3, 4).Reduce(func(a, b int) int { return a + b }))
+
+	// Output:
+	// 10 true
+}
+
+func ExampleStream_Any() {
+	fmt.Println(iter.Of(1, 2, 3).Any(func(x int) bool { return x > 2 }))
+	fmt.Println(iter.Of(1, 2, 3).All(func(x int) bool { return x > 2 }))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for a := range iter.WithContext(ctx, slices.Values([]int{1, 2, 3, 4, 5})) {
+		if a == 3 {
+			cancel()
+		}
+
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleWithContextErr() {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	for a, err := range iter.WithContextErr(ctx, slices.Values([]int{1, 2, 3, 4, 5})) {
+		if err != nil {
+			fmt.Println(err)
+
+			break
+		}
+
+		if a == 2 {
+			cancel(errors.New("stop at 2"))
+		}
+
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// stop at 2
+}
+
+func ExampleValuesContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fmt.Println(iter.ValuesContext(ctx, slices.Values([]int{1, 2, 3})))
+
+	// Output:
+	// [] context canceled
+}
+
+func ExampleTryMap() {
+	seq := iter.TryMap(strconv.Atoi, iter.NoError(slices.Values([]string{"1", "2", "three", "4"})))
+
+	fmt.Println(iter.TryValues(seq))
+	fmt.Println(iter.CollectErrors(seq))
+
+	// Output:
+	// [1 2] strconv.Atoi: parsing "three": invalid syntax
+	// [1 2 4] strconv.Atoi: parsing "three": invalid syntax
+}
+
+func ExampleTryFilter() {
+	seq := iter.TryFilter(func(s string) (bool, error) {
+		i, err := strconv.Atoi(s)
+
+		return i%2 == 0, err
+	}, iter.NoError(slices.Values([]string{"1", "2", "three", "4"})))
+
+	for a, err := range seq {
+		fmt.Printf("%q %v\n", a, err)
+	}
+
+	// Output:
+	// "2" <nil>
+	// "" strconv.Atoi: parsing "three": invalid syntax
+	// "4" <nil>
+}
+
+func ExampleSkipErrors() {
+	seq := iter.TryMap(strconv.Atoi, iter.NoError(slices.Values([]string{"1", "two", "3"})))
+
+	for a := range iter.SkipErrors(seq) {
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 1
+	// 3
+}
+
+func ExampleParallelMap() {
+	fetch := func(_ context.Context, id int) (string, error) {
+		return "user-" + strconv.Itoa(id), nil
+	}
+
+	for user, err := range iter.ParallelMap(context.Background(), 4, fetch, slices.Values([]int{1, 2, 3})) {
+		fmt.Println(user, err)
+	}
+
+	// Output:
+	// user-1 <nil>
+	// user-2 <nil>
+	// user-3 <nil>
+}
+
+func ExampleParallelMapUnordered() {
+	double := func(_ context.Context, x int) (int, error) {
+		return x * 2, nil
+	}
+
+	res, err := iter.TryValues(iter.ParallelMapUnordered(context.Background(), 4, 4, double, slices.Values([]int{1, 2, 3})))
+	slices.Sort(res)
+	fmt.Println(res, err)
+
+	// Output:
+	// [2 4 6] <nil>
+}
+
+func ExampleParallelFilterUnordered() {
+	isEven := func(_ context.Context, x int) (bool, error) {
+		return x%2 == 0, nil
+	}
+
+	res, err := iter.TryValues(iter.ParallelFilterUnordered(context.Background(), 4, 4, isEven, slices.Values([]int{1, 2, 3, 4})))
+	slices.Sort(res)
+	fmt.Println(res, err)
+
+	// Output:
+	// [2 4] <nil>
+}
+
+func ExampleBatch() {
+	for batch, err := range iter.Batch(context.Background(), slices.Values([]int{1, 2, 3, 4, 5}), 2, time.Second) {
+		fmt.Println(batch, err)
+	}
+
+	// Output:
+	// [1 2] <nil>
+	// [3 4] <nil>
+	// [5] <nil>
+}
+
+func ExampleCount() {
+	for id := range iter.From(iter.Count(1000, 5)).Take(3) {
+		fmt.Println(id)
+	}
+
+	// Output:
+	// 1000
+	// 1005
+	// 1010
+}
+
+func ExampleCycle() {
+	fmt.Println(iter.From(iter.Cycle(slices.Values([]string{"a", "b"}))).Take(5).Collect())
+
+	// Output:
+	// [a b a b a]
+}
+
+func ExampleRepeat() {
+	fmt.Println(iter.From(iter.Repeat("x")).Take(3).Collect())
+
+	// Output:
+	// [x x x]
+}
+
+func ExampleRepeatN() {
+	fmt.Println(iter.Values(iter.RepeatN("x", 3)))
+
+	// Output:
+	// [x x x]
+}
+
+func ExampleIterate() {
+	delays := iter.Iterate(100*time.Millisecond, func(d time.Duration) time.Duration { return d * 2 })
+
+	for delay := range iter.ITakeWhile(func(d time.Duration) bool { return d < time.Second }, delays) {
+		fmt.Println(delay)
+	}
+
+	// Output:
+	// 100ms
+	// 200ms
+	// 400ms
+	// 800ms
+}
+
+func ExampleUnfold() {
+	digits := iter.Unfold(1234, func(n int) (int, int, bool) {
+		return n % 10, n / 10, n > 0
+	})
+
+	fmt.Println(iter.Values(digits))
+
+	// Output:
+	// [4 3 2 1]
+}
+
+func ExampleTake2() {
+	for k, v := range iter.Take2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 2) {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// a 1
+	// b 2
+}
+
+func ExampleSkip2() {
+	for k, v := range iter.Skip2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 2) {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// c 3
+}
+
+func ExampleSlice() {
+	fmt.Println(iter.Values(iter.Slice(iter.Count(0, 1), 2, 10, 3)))
+	fmt.Println(iter.Values(iter.Slice(slices.Values([]int{0, 1, 2, 3, 4}), 3, -1, 1)))
+
+	// Output:
+	// [2 5 8]
+	// [3 4]
+}
+
+func ExampleSlice2() {
+	for k, v := range iter.Slice2(iter.Zip([]string{"a", "b", "c", "d"}, []int{1, 2, 3, 4}), 1, 3, 1) {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// b 2
+	// c 3
+}
+
+func ExampleTakeLast() {
+	fmt.Println(iter.Values(iter.TakeLast(slices.Values([]int{1, 2, 3, 4, 5}), 2)))
+
+	// Output:
+	// [4 5]
+}
+
+func ExampleTakeLast2() {
+	for k, v := range iter.TakeLast2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 1) {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// c 3
+}
+
+func ExampleSkipLast() {
+	fmt.Println(iter.Values(iter.SkipLast(slices.Values([]int{1, 2, 3, 4, 5}), 2)))
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleSkipLast2() {
+	for k, v := range iter.SkipLast2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 1) {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// a 1
+	// b 2
+}
+
+func ExampleEnumerate() {
+	for i, s := range iter.Enumerate(iter.IMap(strings.ToUpper, slices.Values([]string{"a", "b", "c"}))) {
+		fmt.Println(i, s)
+	}
+
+	// Output:
+	// 0 A
+	// 1 B
+	// 2 C
+}
+
+func ExampleEnumerateFrom() {
+	for i, s := range iter.EnumerateFrom(slices.Values([]string{"a", "b", "c"}), 1) {
+		fmt.Println(i, s)
+	}
+
+	// Output:
+	// 1 a
+	// 2 b
+	// 3 c
+}
+
+func ExampleFilterIndexed() {
+	everyOther := iter.FilterIndexed(func(i int, _ string) bool { return i%2 == 0 }, slices.Values([]string{"a", "b", "c", "d"}))
+
+	fmt.Println(iter.Values(everyOther))
+
+	// Output:
+	// [a c]
+}
+
+func ExampleMapIndexed() {
+	lines := iter.MapIndexed(func(i int, s string) string { return strconv.Itoa(i+1) + ". " + s }, slices.Values([]string{"a", "b"}))
+
+	for line := range lines {
+		fmt.Println(line)
+	}
+
+	// Output:
+	// 1. a
+	// 2. b
+}
+
+func ExampleTakeWhileIndexed() {
+	fmt.Println(iter.Values(iter.TakeWhileIndexed(func(i, v int) bool { return i == v }, slices.Values([]int{0, 1, 2, 4, 4}))))
+
+	// Output:
+	// [0 1 2]
+}
+
+func ExampleDropWhileIndexed() {
+	fmt.Println(iter.Values(iter.DropWhileIndexed(func(i, v int) bool { return i == v }, slices.Values([]int{0, 1, 2, 4, 4}))))
+
+	// Output:
+	// [4 4]
+}
+
+func ExampleFlatten() {
+	nested := slices.Values([]stdIter.Seq[int]{slices.Values([]int{1, 2}), slices.Values([]int{3})})
+
+	fmt.Println(iter.Values(iter.Flatten(nested)))
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleFlattenSlices() {
+	fmt.Println(iter.Values(iter.FlattenSlices(iter.Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))))
+
+	// Output:
+	// [1 2 3 4 5]
+}
+
+func ExampleFlatMap() {
+	words := iter.FlatMap(func(line string) stdIter.Seq[string] {
+		return slices.Values(strings.Fields(line))
+	}, slices.Values([]string{"a b", "c"}))
+
+	fmt.Println(iter.Values(words))
+
+	// Output:
+	// [a b c]
+}
+
+func ExampleFlatten2() {
+	for k, v := range iter.Flatten2(iter.GroupBy(func(s string) byte { return s[0] }, []string{"apple", "avocado", "banana"})) {
+		fmt.Println(string(k), v)
+	}
+
+	// Output:
+	// a apple
+	// a avocado
+	// b banana
+}
+
+func ExampleRoundRobin() {
+	fmt.Println(iter.Values(iter.RoundRobin(
+		slices.Values([]string{"a", "b", "c"}),
+		slices.Values([]string{"d"}),
+		slices.Values([]string{"e", "f"}),
+	)))
+
+	// Output:
+	// [a d e b f c]
+}
+
+func ExampleInterleave() {
+	fmt.Println(iter.Values(iter.Interleave(
+		slices.Values([]string{"a", "b", "c"}),
+		slices.Values([]string{"1", "2"}),
+	)))
+
+	// Output:
+	// [a 1 b 2 c]
+}
+
+func ExampleIntersperse() {
+	for s := range iter.Intersperse(slices.Values([]string{"a", "b", "c"}), ", ") {
+		fmt.Print(s)
+	}
+
+	fmt.Println()
+
+	// Output:
+	// a, b, c
+}
+
+func ExampleIntersperseFunc() {
+	var n int
+
+	sep := func() string {
+		n++
+
+		return "<" + strconv.Itoa(n) + ">"
+	}
+
+	for s := range iter.IntersperseFunc(slices.Values([]string{"a", "b", "c"}), sep) {
+		fmt.Print(s)
+	}
+
+	fmt.Println()
+
+	// Output:
+	// a<1>b<2>c
 }
@@ -0,0 +1,92 @@
+package iter
+
+import "iter"
+
+// Count returns an infinite sequence of evenly spaced values, starting with
+// start and adding step to get each next value.
+// Use Take or TakeWhile to bound it.
+func Count[T Number](start, step T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := start; yield(v); v += step {
+		}
+	}
+}
+
+// Cycle returns an infinite sequence repeating the elements of the input
+// sequence.
+// The elements of the first pass over the input sequence are buffered, and
+// the next passes are made over the buffer, so that the input sequence is
+// ranged over only once.
+// The resulting sequence is empty if the input sequence is.
+func Cycle[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var saved []T
+
+		for elem := range seq {
+			if !yield(elem) {
+				return
+			}
+
+			saved = append(saved, elem)
+		}
+
+		if len(saved) == 0 {
+			return
+		}
+
+		for {
+			for _, elem := range saved {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Repeat returns an infinite sequence repeating the value.
+func Repeat[T any](v T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for yield(v) {
+		}
+	}
+}
+
+// RepeatN returns a sequence repeating the value n times.
+// The resulting sequence is empty if n is less than 1.
+func RepeatN[T any](v T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < n && yield(v); i++ {
+		}
+	}
+}
+
+// Iterate returns an infinite sequence starting with the seed, and applying
+// the function to each value to get the next one: seed, f(seed),
+// f(f(seed)), and so on.
+func Iterate[T any](seed T, f func(T) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := seed; yield(v); v = f(v) {
+		}
+	}
+}
+
+// Unfold returns a sequence built from the seed by the function.
+// The function receives the current state, starting with the seed, and
+// returns the next element, the next state, and whether the sequence goes on.
+// The resulting sequence stops as soon as the function returns false, the
+// element returned along with false being ignored.
+func Unfold[S, T any](seed S, f func(S) (T, S, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		state := seed
+
+		for {
+			elem, next, ok := f(state)
+			if !ok || !yield(elem) {
+				return
+			}
+
+			state = next
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package iter_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tommoulard/iter"
+)
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		start  int
+		step   int
+		expect []int
+	}{
+		{
+			name:   "count from zero",
+			start:  0,
+			step:   1,
+			expect: []int{0, 1, 2, 3},
+		},
+		{
+			name:   "count with step",
+			start:  1000,
+			step:   5,
+			expect: []int{1000, 1005, 1010, 1015},
+		},
+		{
+			name:   "count backwards",
+			start:  3,
+			step:   -2,
+			expect: []int{3, 1, -1, -3},
+		},
+		{
+			name:   "count with zero step",
+			start:  7,
+			step:   0,
+			expect: []int{7, 7, 7, 7},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.From(iter.Count(test.start, test.step)).Take(4).Collect())
+		})
+	}
+
+	t.Run("count float", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.ITakeWhile(func(f float64) bool { return f < 2 }, iter.Count(0, 0.5)))
+
+		assert.Equal(t, []float64{0, 0.5, 1, 1.5}, got)
+	})
+}
+
+func TestCycle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		expect []int
+	}{
+		{
+			name:   "cycle",
+			a:      []int{1, 2, 3},
+			expect: []int{1, 2, 3, 1, 2, 3, 1},
+		},
+		{
+			name:   "cycle single element",
+			a:      []int{1},
+			expect: []int{1, 1, 1, 1, 1, 1, 1},
+		},
+		{
+			name:   "cycle empty sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.From(iter.Cycle(slices.Values(test.a))).Take(7).Collect())
+		})
+	}
+
+	t.Run("cycle ranges over the input sequence once", func(t *testing.T) {
+		t.Parallel()
+
+		var passes int
+
+		seq := func(yield func(int) bool) {
+			passes++
+
+			for _, elem := range []int{1, 2} {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+
+		assert.Equal(t, []int{1, 2, 1, 2, 1}, iter.From(iter.Cycle(seq)).Take(5).Collect())
+		assert.Equal(t, 1, passes)
+	})
+}
+
+func TestRepeat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a", "a", "a"}, iter.From(iter.Repeat("a")).Take(3).Collect())
+}
+
+func TestRepeatN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		n      int
+		expect []string
+	}{
+		{
+			name:   "repeatn",
+			n:      3,
+			expect: []string{"a", "a", "a"},
+		},
+		{
+			name:   "repeatn zero",
+			n:      0,
+			expect: nil,
+		},
+		{
+			name:   "repeatn negative",
+			n:      -1,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.RepeatN("a", test.n)))
+		})
+	}
+}
+
+func TestIterate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("iterate doubling", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.From(iter.Iterate(time.Second, func(d time.Duration) time.Duration { return d * 2 })).Take(4).Collect()
+
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}, got)
+	})
+
+	t.Run("iterate calls the function lazily", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		got := iter.From(iter.Iterate(1, func(i int) int {
+			calls++
+
+			return i + 1
+		})).Take(3).Collect()
+
+		assert.Equal(t, []int{1, 2, 3}, got)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestUnfold(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unfold fibonacci", func(t *testing.T) {
+		t.Parallel()
+
+		fib := iter.Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+			return s[0], [2]int{s[1], s[0] + s[1]}, true
+		})
+
+		assert.Equal(t, []int{0, 1, 1, 2, 3, 5, 8}, iter.From(fib).Take(7).Collect())
+	})
+
+	t.Run("unfold finite", func(t *testing.T) {
+		t.Parallel()
+
+		digits := iter.Unfold(1234, func(n int) (int, int, bool) {
+			return n % 10, n / 10, n > 0
+		})
+
+		assert.Equal(t, []int{4, 3, 2, 1}, iter.Values(digits))
+	})
+
+	t.Run("unfold empty", func(t *testing.T) {
+		t.Parallel()
+
+		empty := iter.Unfold(0, func(n int) (int, int, bool) {
+			return n, n, false
+		})
+
+		assert.Empty(t, iter.Values(empty))
+	})
+}
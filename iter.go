@@ -1,4 +1,14 @@
 // Package iter provides functions to work with sequences.
+//
+// Unless documented otherwise, the sequences returned by this package keep no
+// state between iterations: they can be ranged over several times, and each
+// iteration starts again from the beginning of the input, as long as the
+// input sequences can themselves be ranged over again.
+//
+// Sequences whose elements may fail to be produced are represented as
+// iter.Seq2[T, error]: each element is paired with the error that occurred
+// while producing it, or nil. Functions prefixed with Try, and the adapters
+// NoError, SkipErrors and Must, work with such fallible sequences.
 package iter
 
 import (
@@ -79,43 +89,124 @@ func IZip[T, U any](seqA iter.Seq[T], seqB iter.Seq[U]) iter.Seq2[T, U] {
 	}
 }
 
-type either[T, U any] any
+// ZipLongest returns a sequence of pairs of elements from the input slices.
+// The resulting sequence is as long as the longest input slice.
+// If one slice is shorter than the other, the missing elements are filled
+// with fillA for the first slice, and fillB for the second one.
+func ZipLongest[T, U any](a []T, b []U, fillA T, fillB U) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for i := range max(len(a), len(b)) {
+			elemA, elemB := fillA, fillB
+			if i < len(a) {
+				elemA = a[i]
+			}
+
+			if i < len(b) {
+				elemB = b[i]
+			}
+
+			if !yield(elemA, elemB) {
+				return
+			}
+		}
+	}
+}
 
-// ZipLongest returns a sequence of pairs of elements from the input
+// IZipLongest returns a sequence of pairs of elements from the input
 // sequences.
 // The resulting sequence is as long as the longest input sequence.
-// If one sequence is shorter than the other, the missing values are filled
-// with the provided fill value.
-func ZipLongest[T, U any](a []T, b []U, fill either[T, U]) iter.Seq2[T, U] {
-	maxLen := max(len(a), len(b))
-
+// If one sequence is shorter than the other, the missing elements are filled
+// with fillA for the first sequence, and fillB for the second one.
+func IZipLongest[T, U any](seqA iter.Seq[T], seqB iter.Seq[U], fillA T, fillB U) iter.Seq2[T, U] {
 	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(seqB)
+		defer stop()
+
+		for a := range seqA {
+			b, ok := next()
+			if !ok {
+				b = fillB
+			}
+
+			if !yield(a, b) {
+				return
+			}
+		}
+
+		for b, ok := next(); ok; b, ok = next() {
+			if !yield(fillA, b) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongestN returns a sequence of elements from the input slices.
+// The resulting sequence contains, for each index, a new slice holding the
+// element at that index of each input slice, in the argument order.
+// It is as long as the longest input slice, the missing elements of the
+// shorter ones being filled with the fill value.
+func ZipLongestN[T any](fill T, a ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var maxLen int
+		for i := range a {
+			maxLen = max(maxLen, len(a[i]))
+		}
+
 		for i := range maxLen {
-			switch {
-			case i < len(a) && i < len(b):
-				if !yield(a[i], b[i]) {
-					return
+			res := make([]T, len(a))
+			for j := range a {
+				res[j] = fill
+				if i < len(a[j]) {
+					res[j] = a[j][i]
 				}
+			}
 
-			case i < len(a):
-				fillU, ok := fill.(U)
-				if !ok {
-					return
-				}
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
 
-				if !yield(a[i], fillU) {
-					return
+// IZipLongestN returns a sequence of elements from the input sequences, as
+// ZipLongestN does.
+// The resulting sequence is as long as the longest input sequence, the
+// missing elements of the shorter ones being filled with the fill value.
+func IZipLongestN[T any](fill T, seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+
+		for i := range seqs {
+			next, stop := iter.Pull(seqs[i])
+			defer stop()
+
+			nexts[i] = next
+		}
+
+		for {
+			res := make([]T, len(seqs))
+			more := false
+
+			for i, next := range nexts {
+				res[i] = fill
+
+				if next == nil {
+					continue
 				}
 
-			case i < len(b):
-				fillT, ok := fill.(T)
+				elem, ok := next()
 				if !ok {
-					return
-				}
+					nexts[i] = nil
 
-				if !yield(fillT, b[i]) {
-					return
+					continue
 				}
+
+				res[i], more = elem, true
+			}
+
+			if !more || !yield(res) {
+				return
 			}
 		}
 	}
@@ -146,6 +237,54 @@ func Accumulate[T cmp.Ordered](a []T) iter.Seq[T] {
 	}
 }
 
+// IAccumulate returns a sequence of accumulated values.
+// The first element is the same as the first element of the input sequence.
+// The second element is the sum of the first and second elements of the input
+// sequence.
+// So on and so forth.
+func IAccumulate[T cmp.Ordered](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var (
+			acc   T
+			first = true
+		)
+
+		for elem := range seq {
+			if first {
+				acc, first = elem, false
+			} else {
+				acc += elem
+			}
+
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// AccumulateFunc returns a sequence of accumulated values.
+// The first element is the result of the operator applied to the initial value
+// and the first element of the input sequence.
+// The second element is the result of the operator applied to the first
+// element of the resulting sequence and the second element of the input
+// sequence.
+// So on and so forth.
+// The resulting sequence is as long as the input sequence, and does not
+// contain the initial value.
+func AccumulateFunc[T, A any](op func(A, T) A, initial A, seq iter.Seq[T]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := initial
+
+		for elem := range seq {
+			acc = op(acc, elem)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
 // Chain returns a sequence of elements from the input sequences.
 // The resulting sequence is the concatenation of the input sequences.
 func Chain[T any](seqs ...[]T) iter.Seq[T] {
@@ -164,10 +303,12 @@ func Chain[T any](seqs ...[]T) iter.Seq[T] {
 // The resulting sequence is the concatenation of the input sequences.
 func ChainSeq[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for i := range seqs {
-			seqs[i](func(elem T) bool {
-				return yield(elem)
-			})
+		for _, seq := range seqs {
+			for elem := range seq {
+				if !yield(elem) {
+					return
+				}
+			}
 		}
 	}
 }
@@ -185,16 +326,52 @@ func Compress[T any](data []T, selectors []bool) iter.Seq[T] {
 	}
 }
 
+// ICompress returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements where the corresponding
+// selector is true.
+// The resulting sequence stops as soon as either input sequence is exhausted.
+func ICompress[T any](data iter.Seq[T], selectors iter.Seq[bool]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem, selected := range IZip(data, selectors) {
+			if selected && !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
 // DropWhile returns a sequence of elements from the input sequence.
 // The resulting sequence contains only the elements after the predicate is
 // false.
 func DropWhile[T any](pred func(T) bool, a []T) iter.Seq[T] {
-	var i int
-	for i = 0; i < len(a) && pred(a[i]); i++ {
+	return func(yield func(T) bool) {
+		i := 0
+		for i < len(a) && pred(a[i]) {
+			i++
+		}
+
+		for ; i < len(a) && yield(a[i]); i++ {
+		}
 	}
+}
 
+// IDropWhile returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements after the predicate is
+// false.
+func IDropWhile[T any](pred func(T) bool, seq iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for ; i < len(a) && yield(a[i]); i++ {
+		dropping := true
+
+		for elem := range seq {
+			if dropping && pred(elem) {
+				continue
+			}
+
+			dropping = false
+
+			if !yield(elem) {
+				return
+			}
 		}
 	}
 }
@@ -211,6 +388,18 @@ func Filter[T any](pred func(T) bool, a []T) iter.Seq[T] {
 	}
 }
 
+// IFilter returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements where the predicate is true.
+func IFilter[T any](pred func(T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range seq {
+			if pred(elem) && !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
 // FilterFalse returns a sequence of elements from the input sequence.
 // The resulting sequence contains only the elements where the predicate is false.
 func FilterFalse[T any](pred func(T) bool, a []T) iter.Seq[T] {
@@ -223,26 +412,153 @@ func FilterFalse[T any](pred func(T) bool, a []T) iter.Seq[T] {
 	}
 }
 
-// GroupBy returns a sequence of groups of elements from the input sequence.
-// The resulting sequence contains groups of elements where the key function
-// returns the same value.
+// IFilterFalse returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements where the predicate is false.
+func IFilterFalse[T any](pred func(T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range seq {
+			if !pred(elem) && !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// GroupBy returns a sequence of groups of elements from the input slice.
+// The resulting sequence contains groups of consecutive elements where the
+// key function returns the same value, in the input order, as Python's
+// itertools.groupby does: a key appears once for each run of elements.
+// Sort the input by key first to get a single group per key, or use
+// GroupByStable.
+// Each group is a sub-slice of the input slice.
 func GroupBy[T any, K comparable](key func(T) K, a []T) iter.Seq2[K, iter.Seq[T]] {
-	groups := make(map[K][]T)
+	return func(yield func(K, iter.Seq[T]) bool) {
+		for start := 0; start < len(a); {
+			k := key(a[start])
+
+			end := start + 1
+			for end < len(a) && key(a[end]) == k {
+				end++
+			}
+
+			if !yield(k, slices.Values(a[start:end])) {
+				return
+			}
+
+			start = end
+		}
+	}
+}
+
+// IGroupBy returns a sequence of groups of elements from the input sequence.
+// The resulting sequence contains groups of consecutive elements where the
+// key function returns the same value, in the input order, as GroupBy does.
+// A group is yielded as soon as the next element has a different key, or the
+// input sequence is exhausted, so that IGroupBy can be used on an unbounded
+// input sequence as long as its groups are finite.
+func IGroupBy[T any, K comparable](key func(T) K, seq iter.Seq[T]) iter.Seq2[K, iter.Seq[T]] {
+	return func(yield func(K, iter.Seq[T]) bool) {
+		var (
+			k     K
+			group []T
+		)
+
+		for elem := range seq {
+			elemKey := key(elem)
+			if len(group) > 0 && elemKey != k {
+				if !yield(k, slices.Values(group)) {
+					return
+				}
+
+				group = nil
+			}
+
+			k = elemKey
+			group = append(group, elem)
+		}
 
-	for i := range a {
-		k := key(a[i])
-		groups[k] = append(groups[k], a[i])
+		if len(group) > 0 {
+			yield(k, slices.Values(group))
+		}
 	}
+}
+
+// GroupByStable returns a sequence of groups of elements from the input slice.
+// The resulting sequence contains one group for each value returned by the
+// key function, holding every element with that key in the input order.
+// The groups are yielded in the order their key first appears.
+// The input slice is fully consumed before the first group is yielded.
+func GroupByStable[T any, K comparable](key func(T) K, a []T) iter.Seq2[K, iter.Seq[T]] {
+	return IGroupByStable(key, slices.Values(a))
+}
 
+// IGroupByStable returns a sequence of groups of elements from the input
+// sequence, as GroupByStable does.
+// The input sequence is fully consumed before the first group is yielded.
+func IGroupByStable[T any, K comparable](key func(T) K, seq iter.Seq[T]) iter.Seq2[K, iter.Seq[T]] {
 	return func(yield func(K, iter.Seq[T]) bool) {
-		for k, group := range groups {
-			if !yield(k, slices.Values(group)) {
+		var keys []K
+
+		groups := make(map[K][]T)
+
+		for elem := range seq {
+			k := key(elem)
+			if _, ok := groups[k]; !ok {
+				keys = append(keys, k)
+			}
+
+			groups[k] = append(groups[k], elem)
+		}
+
+		for _, k := range keys {
+			if !yield(k, slices.Values(groups[k])) {
 				return
 			}
 		}
 	}
 }
 
+// CountBy returns the number of elements of the input sequence for each value
+// returned by the key function.
+func CountBy[T any, K comparable](key func(T) K, seq iter.Seq[T]) map[K]int {
+	return AggregateBy(key, func(n int, _ T) int { return n + 1 }, 0, seq)
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SumBy returns the sum of the values returned by the value function for the
+// elements of the input sequence, for each value returned by the key function.
+func SumBy[T any, K comparable, N Number](key func(T) K, value func(T) N, seq iter.Seq[T]) map[K]N {
+	return AggregateBy(key, func(sum N, elem T) N { return sum + value(elem) }, 0, seq)
+}
+
+// AggregateBy returns the result of applying the reduce function cumulatively
+// to the elements of the input sequence, from left to right, for each value
+// returned by the key function, as Fold does.
+// Each key starts from the initial value, and only the accumulated values are
+// kept, not the elements of the groups.
+func AggregateBy[T any, K comparable, A any](key func(T) K, reduce func(A, T) A, initial A, seq iter.Seq[T]) map[K]A {
+	res := make(map[K]A)
+
+	for elem := range seq {
+		k := key(elem)
+
+		acc, ok := res[k]
+		if !ok {
+			acc = initial
+		}
+
+		res[k] = reduce(acc, elem)
+	}
+
+	return res
+}
+
 // Map returns a sequence of elements from the input sequence.
 // The resulting sequence contains the elements after applying the function to
 // each element of the input sequence.
@@ -299,6 +615,19 @@ func TakeWhile[T any](pred func(T) bool, a []T) iter.Seq[T] {
 	}
 }
 
+// ITakeWhile returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements before the predicate is
+// false.
+func ITakeWhile[T any](pred func(T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range seq {
+			if !pred(elem) || !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
 type pair[T, U any] struct {
 	key T
 	val U
@@ -328,109 +657,293 @@ func ChainMap[T cmp.Ordered, U any](maps ...map[T]U) iter.Seq2[T, U] {
 	}
 }
 
-// Permutations returns a sequence of permutations of the input sequence.
+// Permutations returns a sequence of permutations of the input slice.
 // The resulting sequence contains all possible permutations of the input
-// sequence.
+// slice.
 // This function is a helper for `PermutationsLen(a, len(a))`.
 func Permutations[T any](a []T) iter.Seq[[]T] {
 	return PermutationsLen(a, len(a))
 }
 
-// PermutationsLen returns a sequence of permutations of the input sequence.
+// PermutationsShared returns a sequence of permutations of the input slice,
+// reusing the same slice for every permutation.
+// This function is a helper for `PermutationsLenShared(a, len(a))`.
+func PermutationsShared[T any](a []T) iter.Seq[[]T] {
+	return PermutationsLenShared(a, len(a))
+}
+
+// PermutationsLen returns a sequence of permutations of the input slice.
 // The resulting sequence contains all possible permutations of the input
-// sequence with the specified length.
-// It uses the Heap's algorithm to generate the permutations.
+// slice with the specified length, in lexicographic order of their positions
+// in the input slice.
+// Elements are treated as unique based on their position, not their value.
+// The input slice is not modified.
+// Each permutation is a new slice, see PermutationsLenShared to avoid the
+// allocations.
 func PermutationsLen[T any](a []T, length int) iter.Seq[[]T] {
-	if length == 0 {
-		return func(yield func([]T) bool) {}
-	}
+	return permutations(a, length, false)
+}
 
-	if length > len(a) {
-		return func(yield func([]T) bool) {
-			if !yield(a) {
-				return
+// PermutationsLenShared returns a sequence of permutations of the input
+// slice, as PermutationsLen does.
+// The yielded slices share the same backing array, which is overwritten when
+// the iteration resumes: a permutation is only valid until the next one is
+// yielded, and must be copied to be kept.
+func PermutationsLenShared[T any](a []T, length int) iter.Seq[[]T] {
+	return permutations(a, length, true)
+}
+
+// permutations is the implementation of PermutationsLen and
+// PermutationsLenShared.
+// It permutes indices of the input slice, following the algorithm of Python's
+// itertools.permutations.
+func permutations[T any](a []T, r int, shared bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(a)
+		if r < 0 || r > n {
+			return
+		}
+
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+
+		cycles := make([]int, r)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		buf := make([]T, r)
+		emit := func() bool {
+			if !shared {
+				return yield(pick(a, indices[:r]))
+			}
+
+			for i := range buf {
+				buf[i] = a[indices[i]]
 			}
+
+			return yield(buf)
 		}
-	}
 
-	if length == 1 {
-		return func(yield func([]T) bool) {
-			for i := range a {
-				if !yield([]T{a[i]}) {
+		if !emit() {
+			return
+		}
+
+		for {
+			i := r - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] == 0 {
+					// Move indices[i] to the end, and reset its cycle.
+					first := indices[i]
+					copy(indices[i:], indices[i+1:])
+					indices[n-1] = first
+					cycles[i] = n - i
+
+					continue
+				}
+
+				j := cycles[i]
+				indices[i], indices[n-j] = indices[n-j], indices[i]
+
+				if !emit() {
 					return
 				}
+
+				break
+			}
+
+			if i < 0 {
+				return
 			}
 		}
 	}
+}
 
-	c := make([]int, len(a))
-	i := 0
-
+// Combinations returns a sequence of combinations of elements from the input
+// slice.
+// The resulting sequence contains all the subsequences of r elements of the
+// input slice, in lexicographic order of their positions in the input slice.
+// Elements are treated as unique based on their position, not their value.
+// Each combination is a new slice.
+func Combinations[T any](a []T, r int) iter.Seq[[]T] {
 	return func(yield func([]T) bool) {
-		if !yield(a[:length]) {
+		n := len(a)
+		if r < 0 || r > n {
 			return
 		}
 
-		for i < len(a) {
-			if c[i] >= i {
-				c[i] = 0
-				i++
+		indices := make([]int, r)
+		for i := range indices {
+			indices[i] = i
+		}
 
-				continue
+		for {
+			if !yield(pick(a, indices)) {
+				return
 			}
 
-			if i%2 == 0 {
-				a[i], a[0] = a[0], a[i]
-			} else {
-				a[i], a[c[i]] = a[c[i]], a[i]
+			i := r - 1
+			for i >= 0 && indices[i] == i+n-r {
+				i--
 			}
 
-			if !yield(a[:length]) {
+			if i < 0 {
 				return
 			}
 
-			c[i]++
-			i = 0
+			indices[i]++
+			for j := i + 1; j < r; j++ {
+				indices[j] = indices[j-1] + 1
+			}
 		}
 	}
 }
 
-// Append returns a sequence of elements from the concatenation of the input
-// sequences.
-func Append[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		for i := range seqs {
-			seqs[i](func(elem T) bool {
-				return yield(elem)
-			})
+// CombinationsWithReplacement returns a sequence of combinations of elements
+// from the input slice, allowing individual elements to be repeated.
+// The resulting sequence contains all the sorted selections of r elements of
+// the input slice, in lexicographic order of their positions in the input
+// slice.
+// Elements are treated as unique based on their position, not their value.
+// Each combination is a new slice.
+func CombinationsWithReplacement[T any](a []T, r int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(a)
+		if r < 0 || (n == 0 && r > 0) {
+			return
 		}
-	}
-}
 
-// Append2 returns a sequence of elements from the concatenation of the input
-// sequences.
-func Append2[T, U any](seqs ...iter.Seq2[T, U]) iter.Seq2[T, U] {
-	return func(yield func(T, U) bool) {
-		for i := range seqs {
-			seqs[i](func(elem1 T, elem2 U) bool {
-				return yield(elem1, elem2)
-			})
+		indices := make([]int, r)
+
+		for {
+			if !yield(pick(a, indices)) {
+				return
+			}
+
+			i := r - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			next := indices[i] + 1
+			for j := i; j < r; j++ {
+				indices[j] = next
+			}
 		}
 	}
 }
 
-// First returns a sequence of elements from the input sequence.
-// The resulting sequence contains only the first element of the input sequence.
-func First[T, U any](seq iter.Seq2[T, U]) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		seq(func(elem T, _ U) bool {
-			return yield(elem)
-		})
-	}
-}
+// Product returns a sequence of the cartesian product of the input slices.
+// The resulting sequence contains every slice made of one element of each
+// input slice, in lexicographic order of their positions in the input slices:
+// the last input slice varies the fastest, like nested for loops would.
+// Each product is a new slice.
+func Product[T any](a ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for i := range a {
+			if len(a[i]) == 0 {
+				return
+			}
+		}
 
-// Second returns a sequence of elements from the input sequence.
-// The resulting sequence contains only the second element of the input sequence.
+		indices := make([]int, len(a))
+
+		for {
+			res := make([]T, len(a))
+			for i, j := range indices {
+				res[i] = a[i][j]
+			}
+
+			if !yield(res) {
+				return
+			}
+
+			i := len(a) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(a[i]) {
+					break
+				}
+
+				indices[i] = 0
+			}
+
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// IProduct returns a sequence of the cartesian product of the input
+// sequences, as Product does.
+// The input sequences are collected when the iteration starts, so they must
+// be finite.
+func IProduct[T any](seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		a := make([][]T, len(seqs))
+		for i := range seqs {
+			a[i] = Values(seqs[i])
+		}
+
+		for res := range Product(a...) {
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// pick returns a new slice of the elements of a at the given indices.
+func pick[T any](a []T, indices []int) []T {
+	res := make([]T, len(indices))
+	for i, j := range indices {
+		res[i] = a[j]
+	}
+
+	return res
+}
+
+// Append returns a sequence of elements from the concatenation of the input
+// sequences.
+// It is equivalent to ChainSeq.
+func Append[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return ChainSeq(seqs...)
+}
+
+// Append2 returns a sequence of elements from the concatenation of the input
+// sequences.
+func Append2[T, U any](seqs ...iter.Seq2[T, U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for _, seq := range seqs {
+			for elem1, elem2 := range seq {
+				if !yield(elem1, elem2) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// First returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the first element of the input sequence.
+func First[T, U any](seq iter.Seq2[T, U]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seq(func(elem T, _ U) bool {
+			return yield(elem)
+		})
+	}
+}
+
+// Second returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the second element of the input sequence.
 func Second[T, U any](seq iter.Seq2[T, U]) iter.Seq[U] {
 	return func(yield func(U) bool) {
 		seq(func(_ T, elem U) bool {
@@ -439,14 +952,775 @@ func Second[T, U any](seq iter.Seq2[T, U]) iter.Seq[U] {
 	}
 }
 
+// Filter2 returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains only the pairs where the predicate is true.
+func Filter2[T, U any](pred func(T, U) bool, seq iter.Seq2[T, U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for elem1, elem2 := range seq {
+			if pred(elem1, elem2) && !yield(elem1, elem2) {
+				return
+			}
+		}
+	}
+}
+
+// MapPairs returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the pairs after applying the function to
+// each pair of the input sequence.
+// It is the iter.Seq2 counterpart of IMap.
+func MapPairs[T, U, V, W any](f func(T, U) (V, W), seq iter.Seq2[T, U]) iter.Seq2[V, W] {
+	return func(yield func(V, W) bool) {
+		for elem1, elem2 := range seq {
+			if !yield(f(elem1, elem2)) {
+				return
+			}
+		}
+	}
+}
+
+// MapKeys returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the pairs after applying the function to
+// the first element of each pair, the second element is left untouched.
+func MapKeys[T, U, V any](f func(T) V, seq iter.Seq2[T, U]) iter.Seq2[V, U] {
+	return func(yield func(V, U) bool) {
+		for elem1, elem2 := range seq {
+			if !yield(f(elem1), elem2) {
+				return
+			}
+		}
+	}
+}
+
+// MapValues returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the pairs after applying the function to
+// the second element of each pair, the first element is left untouched.
+func MapValues[T, U, V any](f func(U) V, seq iter.Seq2[T, U]) iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		for elem1, elem2 := range seq {
+			if !yield(elem1, f(elem2)) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile2 returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains only the pairs before the predicate is
+// false.
+func TakeWhile2[T, U any](pred func(T, U) bool, seq iter.Seq2[T, U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for elem1, elem2 := range seq {
+			if !pred(elem1, elem2) || !yield(elem1, elem2) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile2 returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains only the pairs after the predicate is
+// false.
+func DropWhile2[T, U any](pred func(T, U) bool, seq iter.Seq2[T, U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		dropping := true
+
+		for elem1, elem2 := range seq {
+			if dropping && pred(elem1, elem2) {
+				continue
+			}
+
+			dropping = false
+
+			if !yield(elem1, elem2) {
+				return
+			}
+		}
+	}
+}
+
+// Swap returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the pairs of the input sequence with their
+// elements swapped.
+func Swap[T, U any](seq iter.Seq2[T, U]) iter.Seq2[U, T] {
+	return func(yield func(U, T) bool) {
+		for elem1, elem2 := range seq {
+			if !yield(elem2, elem1) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns a sequence of elements from the input sequence.
+// The resulting sequence contains at most the first n elements of the input
+// sequence.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+
+		for elem := range seq {
+			if !yield(elem) {
+				return
+			}
+
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence of elements from the input sequence.
+// The resulting sequence contains the elements of the input sequence after
+// the first n ones.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+
+		for elem := range seq {
+			if i < n {
+				i++
+
+				continue
+			}
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains at most the first n pairs of the input
+// sequence.
+func Take2[T, U any](seq iter.Seq2[T, U], n int) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+
+		for elem1, elem2 := range seq {
+			if !yield(elem1, elem2) {
+				return
+			}
+
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the pairs after the first n ones.
+func Skip2[T, U any](seq iter.Seq2[T, U], n int) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		i := 0
+
+		for elem1, elem2 := range seq {
+			if i < n {
+				i++
+
+				continue
+			}
+
+			if !yield(elem1, elem2) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a sequence of elements from the input sequence, as Python's
+// itertools.islice does.
+// The resulting sequence contains the elements from index start, included, to
+// index stop, excluded, taking one element every step.
+// A negative stop means that there is no upper bound.
+// The input sequence is not consumed past index stop.
+// It panics if start is negative, or if step is less than 1.
+func Slice[T any](seq iter.Seq[T], start, stop, step int) iter.Seq[T] {
+	checkSlice(start, step)
+
+	return func(yield func(T) bool) {
+		if stop >= 0 && stop <= start {
+			return
+		}
+
+		i := 0
+
+		for elem := range seq {
+			if i >= start && (i-start)%step == 0 && !yield(elem) {
+				return
+			}
+
+			i++
+			if stop >= 0 && i >= stop {
+				return
+			}
+		}
+	}
+}
+
+// Slice2 returns a sequence of pairs of elements from the input sequence, as
+// Slice does.
+// It panics if start is negative, or if step is less than 1.
+func Slice2[T, U any](seq iter.Seq2[T, U], start, stop, step int) iter.Seq2[T, U] {
+	return unpairs(Slice(pairs(seq), start, stop, step))
+}
+
+// checkSlice panics if the start or step arguments of Slice are invalid.
+func checkSlice(start, step int) {
+	if start < 0 {
+		panic("iter: start cannot be negative")
+	}
+
+	if step < 1 {
+		panic("iter: step cannot be less than 1")
+	}
+}
+
+// TakeLast returns a sequence of elements from the input sequence.
+// The resulting sequence contains at most the last n elements of the input
+// sequence.
+// The input sequence is fully consumed before the first element is yielded,
+// only the last n elements being kept.
+func TakeLast[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		r := ring[T]{n: n}
+
+		for elem := range seq {
+			r.push(elem)
+		}
+
+		for i := range len(r.buf) {
+			if !yield(r.buf[(r.start+i)%len(r.buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// TakeLast2 returns a sequence of pairs of elements from the input sequence,
+// as TakeLast does.
+func TakeLast2[T, U any](seq iter.Seq2[T, U], n int) iter.Seq2[T, U] {
+	return unpairs(TakeLast(pairs(seq), n))
+}
+
+// SkipLast returns a sequence of elements from the input sequence.
+// The resulting sequence contains the elements except the last n ones.
+// Each element is yielded once n more elements have been read, only the last
+// n elements being kept.
+func SkipLast[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			for elem := range seq {
+				if !yield(elem) {
+					return
+				}
+			}
+
+			return
+		}
+
+		r := ring[T]{n: n}
+
+		for elem := range seq {
+			if oldest, ok := r.push(elem); ok && !yield(oldest) {
+				return
+			}
+		}
+	}
+}
+
+// SkipLast2 returns a sequence of pairs of elements from the input sequence,
+// as SkipLast does.
+func SkipLast2[T, U any](seq iter.Seq2[T, U], n int) iter.Seq2[T, U] {
+	return unpairs(SkipLast(pairs(seq), n))
+}
+
+// Enumerate returns a sequence of pairs of elements from the input sequence.
+// The resulting sequence contains the index of each element of the input
+// sequence, starting at 0, paired with the element.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return EnumerateFrom(seq, 0)
+}
+
+// EnumerateFrom returns a sequence of pairs of elements from the input
+// sequence, as Enumerate does, the indices starting at start.
+func EnumerateFrom[T any](seq iter.Seq[T], start int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := start
+
+		for elem := range seq {
+			if !yield(i, elem) {
+				return
+			}
+
+			i++
+		}
+	}
+}
+
+// FilterIndexed returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements where the predicate is
+// true, the predicate receiving the index of each element along with it.
+func FilterIndexed[T any](pred func(int, T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return Second(Filter2(pred, Enumerate(seq)))
+}
+
+// MapIndexed returns a sequence of elements from the input sequence.
+// The resulting sequence contains the elements after applying the function to
+// each element of the input sequence and its index.
+func MapIndexed[T, U any](f func(int, T) U, seq iter.Seq[T]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for i, elem := range Enumerate(seq) {
+			if !yield(f(i, elem)) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhileIndexed returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements before the predicate is
+// false, the predicate receiving the index of each element along with it.
+func TakeWhileIndexed[T any](pred func(int, T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return Second(TakeWhile2(pred, Enumerate(seq)))
+}
+
+// DropWhileIndexed returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the elements after the predicate is
+// false, the predicate receiving the index of each element along with it.
+func DropWhileIndexed[T any](pred func(int, T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return Second(DropWhile2(pred, Enumerate(seq)))
+}
+
+// Flatten returns a sequence of elements from the input sequence of
+// sequences.
+// The resulting sequence is the concatenation of the inner sequences, as
+// ChainSeq does.
+func Flatten[T any](seq iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for inner := range seq {
+			for elem := range inner {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlattenSlices returns a sequence of elements from the input sequence of
+// slices.
+// The resulting sequence is the concatenation of the slices.
+func FlattenSlices[T any](seq iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for inner := range seq {
+			for _, elem := range inner {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap returns a sequence of elements from the input sequence.
+// The resulting sequence is the concatenation of the sequences returned by the
+// function for each element of the input sequence.
+func FlatMap[T, U any](f func(T) iter.Seq[U], seq iter.Seq[T]) iter.Seq[U] {
+	return Flatten(IMap(f, seq))
+}
+
+// Flatten2 returns a sequence of pairs of elements from the input sequence of
+// keys and sequences, such as the one returned by GroupBy.
+// The resulting sequence contains each element of the inner sequences, paired
+// with the key of its sequence.
+func Flatten2[K, V any](seq iter.Seq2[K, iter.Seq[V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, inner := range seq {
+			for v := range inner {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Intersperse returns a sequence of elements from the input sequence.
+// The resulting sequence contains the separator between each pair of
+// consecutive elements of the input sequence.
+func Intersperse[T any](seq iter.Seq[T], sep T) iter.Seq[T] {
+	return IntersperseFunc(seq, func() T { return sep })
+}
+
+// IntersperseFunc returns a sequence of elements from the input sequence, as
+// Intersperse does, the separators being returned by the function.
+// The function is called once for each separator, only when the element
+// following it is read.
+func IntersperseFunc[T any](seq iter.Seq[T], sep func() T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		first := true
+
+		for elem := range seq {
+			if !first && !yield(sep()) {
+				return
+			}
+
+			first = false
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// ring is a ring buffer keeping the last n elements pushed to it, the oldest
+// one being at index start.
+// buf grows with the elements pushed until it holds n of them, so that a large
+// n does not allocate more than the input sequence holds.
+type ring[T any] struct {
+	buf   []T
+	start int
+	n     int
+}
+
+// push adds the element to the ring buffer.
+// If the buffer is full, it replaces the oldest element, and returns it
+// along with true.
+func (r *ring[T]) push(elem T) (T, bool) {
+	if len(r.buf) < r.n {
+		r.buf = append(r.buf, elem)
+
+		var zero T
+
+		return zero, false
+	}
+
+	oldest := r.buf[r.start]
+	r.buf[r.start] = elem
+	r.start = (r.start + 1) % len(r.buf)
+
+	return oldest, true
+}
+
+// pairs returns a sequence of the pairs of elements of the input sequence.
+func pairs[T, U any](seq iter.Seq2[T, U]) iter.Seq[pair[T, U]] {
+	return func(yield func(pair[T, U]) bool) {
+		for k, v := range seq {
+			if !yield(pair[T, U]{key: k, val: v}) {
+				return
+			}
+		}
+	}
+}
+
+// unpairs returns a sequence of the pairs of elements held by the input
+// sequence, as the inverse of pairs does.
+func unpairs[T, U any](seq iter.Seq[pair[T, U]]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for p := range seq {
+			if !yield(p.key, p.val) {
+				return
+			}
+		}
+	}
+}
+
+// Distinct returns a sequence of elements from the input sequence.
+// The resulting sequence contains only the first occurrence of each element.
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+
+		for elem := range seq {
+			if _, ok := seen[elem]; ok {
+				continue
+			}
+
+			seen[elem] = struct{}{}
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns a sequence of consecutive chunks of elements from the input
+// sequence.
+// Each chunk has n elements, except the last one which may be shorter.
+// Each chunk is a new slice.
+// It panics if n is less than 1.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("iter: n cannot be less than 1")
+	}
+
+	return func(yield func([]T) bool) {
+		var chunk []T
+
+		for elem := range seq {
+			chunk = append(chunk, elem)
+			if len(chunk) < n {
+				continue
+			}
+
+			if !yield(chunk) {
+				return
+			}
+
+			chunk = nil
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Windows returns a sequence of windows of elements from the input sequence.
+// Each window has size consecutive elements, and starts step elements after
+// the previous one: windows overlap when step is less than size, and elements
+// are skipped when step is greater than size.
+// Only complete windows are yielded.
+// Each window is a new slice, see WindowsShared to avoid the allocations.
+// It panics if size or step is less than 1.
+func Windows[T any](seq iter.Seq[T], size, step int) iter.Seq[[]T] {
+	return windows(seq, size, step, false)
+}
+
+// WindowsShared returns a sequence of windows of elements from the input
+// sequence, as Windows does.
+// The yielded slices share the same backing array, which is overwritten when
+// the iteration resumes: a window is only valid until the next one is
+// yielded, and must be copied to be kept.
+// It panics if size or step is less than 1.
+func WindowsShared[T any](seq iter.Seq[T], size, step int) iter.Seq[[]T] {
+	return windows(seq, size, step, true)
+}
+
+// Window returns a sequence of sliding windows of elements from the input
+// sequence.
+// Each window has size consecutive elements, and starts one element after the
+// previous one.
+// This function is a helper for `Windows(seq, size, 1)`.
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return Windows(seq, size, 1)
+}
+
+// Tumbling returns a sequence of non-overlapping windows of elements from the
+// input sequence.
+// Each window has size consecutive elements, the trailing elements that do not
+// fill a complete window are dropped, see Chunk to keep them.
+// This function is a helper for `Windows(seq, size, size)`.
+func Tumbling[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return Windows(seq, size, size)
+}
+
+// Pairwise returns a sequence of pairs of consecutive elements from the input
+// sequence.
+// The resulting sequence is one element shorter than the input sequence.
+func Pairwise[T any](seq iter.Seq[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		var (
+			prev    T
+			started bool
+		)
+
+		for elem := range seq {
+			if started && !yield(prev, elem) {
+				return
+			}
+
+			prev, started = elem, true
+		}
+	}
+}
+
+func windows[T any](seq iter.Seq[T], size, step int, shared bool) iter.Seq[[]T] {
+	if size < 1 {
+		panic("iter: size cannot be less than 1")
+	}
+
+	if step < 1 {
+		panic("iter: step cannot be less than 1")
+	}
+
+	return func(yield func([]T) bool) {
+		var buf []T
+
+		skip := 0
+
+		for elem := range seq {
+			if skip > 0 {
+				skip--
+
+				continue
+			}
+
+			buf = append(buf, elem)
+			if len(buf) < size {
+				continue
+			}
+
+			window := buf
+			if !shared {
+				window = slices.Clone(buf)
+			}
+
+			if !yield(window) {
+				return
+			}
+
+			if step >= size {
+				skip = step - size
+				buf = buf[:0]
+
+				continue
+			}
+
+			buf = buf[:copy(buf, buf[step:])]
+		}
+	}
+}
+
+// Reduce returns the result of applying the function cumulatively to the
+// elements of the input sequence, from left to right.
+// The first element of the input sequence is used as the initial value.
+// It returns false if the input sequence is empty.
+func Reduce[T any](f func(T, T) T, seq iter.Seq[T]) (T, bool) {
+	var (
+		acc T
+		ok  bool
+	)
+
+	for elem := range seq {
+		if !ok {
+			acc, ok = elem, true
+
+			continue
+		}
+
+		acc = f(acc, elem)
+	}
+
+	return acc, ok
+}
+
+// Fold returns the result of applying the function cumulatively to the
+// elements of the input sequence, from left to right, starting with the
+// initial value.
+// It returns the initial value if the input sequence is empty.
+// It is the last value of `AccumulateFunc(f, initial, seq)`.
+func Fold[T, A any](f func(A, T) A, initial A, seq iter.Seq[T]) A {
+	acc := initial
+
+	for elem := range seq {
+		acc = f(acc, elem)
+	}
+
+	return acc
+}
+
+// Equal reports whether the input sequences are equal: they have the same
+// length and their elements are equal, pairwise.
+// It stops at the first difference.
 func Equal[T comparable](seqA, seqB iter.Seq[T]) bool {
-	for aa, bb := range IZip(seqA, seqB) {
-		if aa != bb {
+	return EqualFunc(seqA, seqB, func(a, b T) bool { return a == b })
+}
+
+// EqualFunc reports whether the input sequences are equal, using the equality
+// function to compare their elements, as slices.EqualFunc does.
+// It stops at the first difference.
+func EqualFunc[T, U any](seqA iter.Seq[T], seqB iter.Seq[U], eq func(T, U) bool) bool {
+	next, stop := iter.Pull(seqB)
+	defer stop()
+
+	for a := range seqA {
+		b, ok := next()
+		if !ok || !eq(a, b) {
+			return false
+		}
+	}
+
+	_, ok := next()
+
+	return !ok
+}
+
+// Equal2 reports whether the input sequences of pairs are equal: they have the
+// same length and their pairs of elements are equal, pairwise.
+// It stops at the first difference.
+func Equal2[T, U comparable](seqA, seqB iter.Seq2[T, U]) bool {
+	next, stop := iter.Pull2(seqB)
+	defer stop()
+
+	for a1, a2 := range seqA {
+		b1, b2, ok := next()
+		if !ok || a1 != b1 || a2 != b2 {
 			return false
 		}
 	}
 
-	return true
+	_, _, ok := next()
+
+	return !ok
+}
+
+// Compare compares the elements of the input sequences, using cmp.Compare on
+// each pair of elements, as slices.Compare does.
+// The elements are compared sequentially, starting at the first one, until
+// one element differs from the other.
+// If a sequence is a prefix of the other, the shorter sequence is less than
+// the longer one.
+// The result is 0 if seqA == seqB, -1 if seqA < seqB, and +1 if seqA > seqB.
+// It stops at the first difference.
+func Compare[T cmp.Ordered](seqA, seqB iter.Seq[T]) int {
+	return CompareFunc(seqA, seqB, cmp.Compare[T])
+}
+
+// CompareFunc is like Compare but uses a custom comparison function on each
+// pair of elements, as slices.CompareFunc does.
+// The result is the first non-zero result of cmp; if cmp always returns 0
+// the result is 0 if the sequences have the same length, -1 if seqA is
+// shorter, and +1 if seqA is longer.
+func CompareFunc[T, U any](seqA iter.Seq[T], seqB iter.Seq[U], cmp func(T, U) int) int {
+	next, stop := iter.Pull(seqB)
+	defer stop()
+
+	for a := range seqA {
+		b, ok := next()
+		if !ok {
+			return 1
+		}
+
+		if c := cmp(a, b); c != 0 {
+			return c
+		}
+	}
+
+	if _, ok := next(); ok {
+		return -1
+	}
+
+	return 0
 }
 
 func Len[T any](seq iter.Seq[T]) int {
@@ -1,8 +1,11 @@
 package iter_test
 
 import (
+	"cmp"
 	stdIter "iter"
+	"math"
 	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -239,7 +242,8 @@ func TestZipLongest(t *testing.T) {
 		name   string
 		a      []any
 		b      []any
-		fill   any
+		fillA  any
+		fillB  any
 		expect [][]any
 		length int
 	}{
@@ -247,7 +251,8 @@ func TestZipLongest(t *testing.T) {
 			name:   "ziplongest two slices of int",
 			a:      []any{4, 5, 6},
 			b:      []any{7, 8, 9},
-			fill:   42,
+			fillA:  42,
+			fillB:  43,
 			expect: [][]any{{4, 7}, {5, 8}, {6, 9}},
 			length: 3,
 		},
@@ -255,7 +260,8 @@ func TestZipLongest(t *testing.T) {
 			name:   "ziplongest one slices of string, and one slice of int",
 			a:      []any{"a", "b", "c"},
 			b:      []any{1, 2, 3},
-			fill:   "0",
+			fillA:  "0",
+			fillB:  0,
 			expect: [][]any{{"a", 1}, {"b", 2}, {"c", 3}},
 			length: 3,
 		},
@@ -263,25 +269,34 @@ func TestZipLongest(t *testing.T) {
 			name:   "ziplongest two slices of int with different length len(a) < len(b)",
 			a:      []any{4, 5, 6},
 			b:      []any{7, 8},
-			fill:   42,
-			expect: [][]any{{4, 7}, {5, 8}, {6, 42}},
+			fillA:  42,
+			fillB:  43,
+			expect: [][]any{{4, 7}, {5, 8}, {6, 43}},
 			length: 3,
 		},
 		{
 			name:   "ziplongest two slices of int with different length len(a) > len(b)",
 			a:      []any{7, 8},
 			b:      []any{4, 5, 6},
-			fill:   42,
+			fillA:  42,
+			fillB:  43,
 			expect: [][]any{{7, 4}, {8, 5}, {42, 6}},
 			length: 3,
 		},
 		{
-			name:   "ziplongest with fill of different type",
-			a:      []any{7, 8},
-			b:      []any{4, 5, 6},
-			fill:   "42",
-			expect: [][]any{{7, 4}, {8, 5}, {"42", 6}},
-			length: 3,
+			name:   "ziplongest with empty slice",
+			a:      []any{},
+			b:      []any{4, 5},
+			fillA:  42,
+			fillB:  43,
+			expect: [][]any{{42, 4}, {42, 5}},
+			length: 2,
+		},
+		{
+			name:   "ziplongest two empty slices",
+			a:      []any{},
+			b:      []any{},
+			length: 0,
 		},
 	}
 
@@ -291,7 +306,7 @@ func TestZipLongest(t *testing.T) {
 
 			i := 0
 
-			for a, b := range iter.ZipLongest(test.a, test.b, test.fill) {
+			for a, b := range iter.ZipLongest(test.a, test.b, test.fillA, test.fillB) {
 				assert.Equal(t, test.expect[i][0], a)
 				assert.Equal(t, test.expect[i][1], b)
 
@@ -301,6 +316,158 @@ func TestZipLongest(t *testing.T) {
 			assert.Equal(t, test.length, i)
 		})
 	}
+
+	t.Run("ziplongest with fills of different types", func(t *testing.T) {
+		t.Parallel()
+
+		gotA, gotB := iter.Values2(iter.ZipLongest([]string{"a"}, []int{1, 2}, "-", 0))
+
+		assert.Equal(t, []string{"a", "-"}, gotA)
+		assert.Equal(t, []int{1, 2}, gotB)
+	})
+}
+
+func TestIZipLongest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		a       []string
+		b       []int
+		expectA []string
+		expectB []int
+	}{
+		{
+			name:    "izip longest same length",
+			a:       []string{"a", "b"},
+			b:       []int{1, 2},
+			expectA: []string{"a", "b"},
+			expectB: []int{1, 2},
+		},
+		{
+			name:    "izip longest len(a) > len(b)",
+			a:       []string{"a", "b", "c"},
+			b:       []int{1},
+			expectA: []string{"a", "b", "c"},
+			expectB: []int{1, 0, 0},
+		},
+		{
+			name:    "izip longest len(a) < len(b)",
+			a:       []string{"a"},
+			b:       []int{1, 2, 3},
+			expectA: []string{"a", "-", "-"},
+			expectB: []int{1, 2, 3},
+		},
+		{
+			name: "izip longest empty sequences",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotA, gotB := iter.Values2(iter.IZipLongest(slices.Values(test.a), slices.Values(test.b), "-", 0))
+
+			assert.Equal(t, test.expectA, gotA)
+			assert.Equal(t, test.expectB, gotB)
+		})
+	}
+}
+
+func TestZipLongestN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]int
+		expect [][]int
+	}{
+		{
+			name:   "ziplongestn three slices",
+			a:      [][]int{{1, 2, 3}, {4}, {5, 6}},
+			expect: [][]int{{1, 4, 5}, {2, -1, 6}, {3, -1, -1}},
+		},
+		{
+			name:   "ziplongestn one slice",
+			a:      [][]int{{1, 2}},
+			expect: [][]int{{1}, {2}},
+		},
+		{
+			name:   "ziplongestn empty slices",
+			a:      [][]int{{}, {}},
+			expect: nil,
+		},
+		{
+			name:   "ziplongestn no slice",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.ZipLongestN(-1, test.a...)))
+		})
+	}
+}
+
+func TestIZipLongestN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]int
+		expect [][]int
+	}{
+		{
+			name:   "izip longest n three sequences",
+			a:      [][]int{{1, 2, 3}, {4}, {5, 6}},
+			expect: [][]int{{1, 4, 5}, {2, -1, 6}, {3, -1, -1}},
+		},
+		{
+			name:   "izip longest n empty sequences",
+			a:      [][]int{{}, {}},
+			expect: nil,
+		},
+		{
+			name:   "izip longest n no sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seqs := make([]stdIter.Seq[int], len(test.a))
+			for i := range test.a {
+				seqs[i] = slices.Values(test.a[i])
+			}
+
+			assert.Equal(t, test.expect, iter.Values(iter.IZipLongestN(-1, seqs...)))
+		})
+	}
+
+	t.Run("izip longest n stops the input sequences", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped int
+
+		seq := func(yield func(int) bool) {
+			defer func() { stopped++ }()
+
+			for i := 0; yield(i); i++ {
+			}
+		}
+
+		for range iter.IZipLongestN(0, seq, seq) {
+			break
+		}
+
+		assert.Equal(t, 2, stopped)
+	})
 }
 
 func TestAccumulate(t *testing.T) {
@@ -389,6 +556,105 @@ func TestAccumulate(t *testing.T) {
 	})
 }
 
+func TestIAccumulate(t *testing.T) {
+	t.Parallel()
+
+	// Cannot do table tests because any does not satisfy cmp.Ordered
+
+	t.Run("iaccumulate int", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.IAccumulate(slices.Values([]int{1, 2, 3, 4, 5})))
+		assert.Equal(t, []int{1, 3, 6, 10, 15}, got)
+	})
+
+	t.Run("iaccumulate string", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.IAccumulate(slices.Values([]string{"a", "b", "c"})))
+		assert.Equal(t, []string{"a", "ab", "abc"}, got)
+	})
+
+	t.Run("iaccumulate empty sequence", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.IAccumulate(slices.Values([]int{})))
+		assert.Empty(t, got)
+	})
+
+	t.Run("iaccumulate with break", func(t *testing.T) {
+		t.Parallel()
+
+		var got []int
+
+		for s := range iter.IAccumulate(slices.Values([]int{1, 2, 3, 4, 5})) {
+			if s > 5 {
+				break
+			}
+
+			got = append(got, s)
+		}
+
+		assert.Equal(t, []int{1, 3}, got)
+	})
+}
+
+func TestAccumulateFunc(t *testing.T) {
+	t.Parallel()
+
+	maxInt := func(a, b int) int { return max(a, b) }
+
+	t.Run("running max", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.AccumulateFunc(maxInt, 0, slices.Values([]int{3, 1, 4, 1, 5, 9, 2})))
+		assert.Equal(t, []int{3, 3, 4, 4, 5, 9, 9}, got)
+	})
+
+	t.Run("running product", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.AccumulateFunc(func(a, b int) int { return a * b }, 1, slices.Values([]int{1, 2, 3, 4})))
+		assert.Equal(t, []int{1, 2, 6, 24}, got)
+	})
+
+	t.Run("join with separator", func(t *testing.T) {
+		t.Parallel()
+
+		join := func(acc, s string) string {
+			if acc == "" {
+				return s
+			}
+
+			return acc + ", " + s
+		}
+
+		got := iter.Values(iter.AccumulateFunc(join, "", slices.Values([]string{"a", "b", "c"})))
+		assert.Equal(t, []string{"a", "a, b", "a, b, c"}, got)
+	})
+
+	t.Run("struct aggregation", func(t *testing.T) {
+		t.Parallel()
+
+		type stats struct {
+			count int
+			sum   float64
+		}
+
+		got := iter.Values(iter.AccumulateFunc(func(s stats, f float64) stats {
+			return stats{count: s.count + 1, sum: s.sum + f}
+		}, stats{}, slices.Values([]float64{1, 2, 3})))
+		assert.Equal(t, []stats{{1, 1}, {2, 3}, {3, 6}}, got)
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.AccumulateFunc(maxInt, 42, slices.Values([]int{})))
+		assert.Empty(t, got)
+	})
+}
+
 func TestChain(t *testing.T) {
 	t.Parallel()
 
@@ -474,6 +740,12 @@ func TestChainSeq(t *testing.T) {
 			expect: []any{4, 5, 6},
 			length: 3,
 		},
+		{
+			name:   "chain no sequence",
+			seqs:   nil,
+			expect: nil,
+			length: 0,
+		},
 	}
 
 	for _, test := range tests {
@@ -552,6 +824,51 @@ func TestCompress(t *testing.T) {
 	}
 }
 
+func TestICompress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      stdIter.Seq[any]
+		selectors stdIter.Seq[bool]
+		expect    []any
+	}{
+		{
+			name:      "icompress with some true",
+			data:      slices.Values([]any{1, 2, 3, 4, 5}),
+			selectors: slices.Values([]bool{true, false, true, false, true}),
+			expect:    []any{1, 3, 5},
+		},
+		{
+			name:      "icompress with all false",
+			data:      slices.Values([]any{1, 2, 3}),
+			selectors: slices.Values([]bool{false, false, false}),
+			expect:    nil,
+		},
+		{
+			name:      "icompress with fewer selectors than data",
+			data:      slices.Values([]any{1, 2, 3, 4, 5}),
+			selectors: slices.Values([]bool{true, true}),
+			expect:    []any{1, 2},
+		},
+		{
+			name:      "icompress with fewer data than selectors",
+			data:      slices.Values([]any{1, 2}),
+			selectors: slices.Values([]bool{false, true, true, true}),
+			expect:    []any{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.ICompress(test.data, test.selectors))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
 func TestDropWhile(t *testing.T) {
 	t.Parallel()
 
@@ -609,6 +926,51 @@ func TestDropWhile(t *testing.T) {
 	}
 }
 
+func TestIDropWhile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(any) bool
+		a      stdIter.Seq[any]
+		expect []any
+	}{
+		{
+			name: "idrop while int",
+			pred: func(i any) bool {
+				return i.(int) < 3
+			},
+			a:      slices.Values([]any{1, 2, 3, 4, 1}),
+			expect: []any{3, 4, 1},
+		},
+		{
+			name: "idrop while string",
+			pred: func(s any) bool {
+				return s.(string) != "c"
+			},
+			a:      slices.Values([]any{"a", "b", "c", "d", "e", "c"}),
+			expect: []any{"c", "d", "e", "c"},
+		},
+		{
+			name: "idrop while always true",
+			pred: func(any) bool {
+				return true
+			},
+			a:      slices.Values([]any{1, 2, 3}),
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.IDropWhile(test.pred, test.a))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
 func TestFilter(t *testing.T) {
 	t.Parallel()
 
@@ -666,6 +1028,51 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestIFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(any) bool
+		a      stdIter.Seq[any]
+		expect []any
+	}{
+		{
+			name: "ifilter int",
+			pred: func(i any) bool {
+				return i.(int) < 3
+			},
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			expect: []any{1, 2},
+		},
+		{
+			name: "ifilter string",
+			pred: func(s any) bool {
+				return s.(string) != "c"
+			},
+			a:      slices.Values([]any{"a", "b", "c", "d", "e", "c"}),
+			expect: []any{"a", "b", "d", "e"},
+		},
+		{
+			name: "ifilter empty sequence",
+			pred: func(any) bool {
+				return true
+			},
+			a:      slices.Values([]any{}),
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.IFilter(test.pred, test.a))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
 func TestFilterFalse(t *testing.T) {
 	t.Parallel()
 
@@ -723,51 +1130,160 @@ func TestFilterFalse(t *testing.T) {
 	}
 }
 
-func TestGroupBy(t *testing.T) {
+func TestIFilterFalse(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name   string
-		key    func(any) any
-		a      []any
-		expect map[any][]any
+		pred   func(any) bool
+		a      stdIter.Seq[any]
+		expect []any
 	}{
 		{
-			name: "groupby int",
-			key: func(i any) any {
+			name: "ifilter false int",
+			pred: func(i any) bool {
+				return i.(int) < 3
+			},
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			expect: []any{3, 4, 5},
+		},
+		{
+			name: "ifilter false string",
+			pred: func(s any) bool {
+				return s.(string) != "c"
+			},
+			a:      slices.Values([]any{"a", "b", "c", "d", "e", "c"}),
+			expect: []any{"c", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.IFilterFalse(test.pred, test.a))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+// groups returns the keys and the elements of the groups of the input
+// sequence.
+func groups[K, T any](seq stdIter.Seq2[K, stdIter.Seq[T]]) ([]K, [][]T) {
+	var (
+		keys   []K
+		values [][]T
+	)
+
+	for key, group := range seq {
+		keys = append(keys, key)
+		values = append(values, iter.Values(group))
+	}
+
+	return keys, values
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		key          func(any) any
+		a            []any
+		expectKeys   []any
+		expectGroups [][]any
+	}{
+		{
+			name: "groupby int",
+			key: func(i any) any {
 				return i.(int) % 2
 			},
-			a: []any{1, 2, 3, 4, 5},
-			expect: map[any][]any{
-				0: {2, 4},
-				1: {1, 3, 5},
-			},
-		},
-		// {
-		// name: "groupby string",
-		// key: func(s any) any {
-		// return s.(string)[0]
-		// },
-		// a: []any{"apple", "banana", "avocado", "cherry", "blueberry"},
-		// expect: map[any][]any{
-		// 'a': {"apple", "avocado"},
-		// 'b': {"banana", "blueberry"},
-		// 'c': {"cherry"},
-		// },
-		// },
+			a:            []any{1, 3, 2, 4, 5},
+			expectKeys:   []any{1, 0, 1},
+			expectGroups: [][]any{{1, 3}, {2, 4}, {5}},
+		},
+		{
+			name: "groupby string",
+			key: func(s any) any {
+				return s.(string)[0]
+			},
+			a:            []any{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"},
+			expectKeys:   []any{byte('a'), byte('b'), byte('c'), byte('a')},
+			expectGroups: [][]any{{"apple", "avocado"}, {"banana", "blueberry"}, {"cherry"}, {"apricot"}},
+		},
 		{
 			name: "groupby rune",
 			key: func(r any) any {
 				return r.(rune)
 			},
-			a: []any{'a', 'b', 'c', 'd', 'e'},
-			expect: map[any][]any{
-				'a': {'a'},
-				'b': {'b'},
-				'c': {'c'},
-				'd': {'d'},
-				'e': {'e'},
+			a:            []any{'a', 'b', 'c', 'd', 'e'},
+			expectKeys:   []any{'a', 'b', 'c', 'd', 'e'},
+			expectGroups: [][]any{{'a'}, {'b'}, {'c'}, {'d'}, {'e'}},
+		},
+		{
+			name: "groupby single group",
+			key: func(any) any {
+				return 0
+			},
+			a:            []any{1, 2, 3},
+			expectKeys:   []any{0},
+			expectGroups: [][]any{{1, 2, 3}},
+		},
+		{
+			name: "groupby empty slice",
+			key: func(i any) any {
+				return i
+			},
+			a: []any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, groups := groups(iter.GroupBy(test.key, test.a))
+
+			assert.Equal(t, test.expectKeys, keys)
+			assert.Equal(t, test.expectGroups, groups)
+		})
+	}
+}
+
+func TestIGroupBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		key          func(any) any
+		a            stdIter.Seq[any]
+		expectKeys   []any
+		expectGroups [][]any
+	}{
+		{
+			name: "igroupby int",
+			key: func(i any) any {
+				return i.(int) % 2
+			},
+			a:            slices.Values([]any{1, 3, 2, 4, 5}),
+			expectKeys:   []any{1, 0, 1},
+			expectGroups: [][]any{{1, 3}, {2, 4}, {5}},
+		},
+		{
+			name: "igroupby single group",
+			key: func(any) any {
+				return 0
+			},
+			a:            slices.Values([]any{1, 2, 3}),
+			expectKeys:   []any{0},
+			expectGroups: [][]any{{1, 2, 3}},
+		},
+		{
+			name: "igroupby empty sequence",
+			key: func(i any) any {
+				return i
 			},
+			a: slices.Values([]any{}),
 		},
 	}
 
@@ -775,18 +1291,251 @@ func TestGroupBy(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			t.Logf("expect: %v", test.expect)
+			keys, groups := groups(iter.IGroupBy(test.key, test.a))
+
+			assert.Equal(t, test.expectKeys, keys)
+			assert.Equal(t, test.expectGroups, groups)
+		})
+	}
+
+	t.Run("igroupby unbounded sequence", func(t *testing.T) {
+		t.Parallel()
 
-			for key, group := range iter.GroupBy(test.key, test.a) {
-				t.Logf("key: %v, group: %v, expect: %v", key, iter.Values(group), test.expect)
+		naturals := func(yield func(int) bool) {
+			for i := 0; yield(i); i++ {
+			}
+		}
 
-				expected, ok := test.expect[key]
-				require.True(t, ok)
+		var groups [][]int
 
-				assert.ElementsMatch(t, expected, iter.Values(group))
+		for _, group := range iter.IGroupBy(func(i int) int { return i / 3 }, naturals) {
+			groups = append(groups, iter.Values(group))
+			if len(groups) == 2 {
+				break
 			}
+		}
+
+		assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}}, groups)
+	})
+}
+
+func TestGroupByStable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		key          func(any) any
+		a            []any
+		expectKeys   []any
+		expectGroups [][]any
+	}{
+		{
+			name: "groupbystable int",
+			key: func(i any) any {
+				return i.(int) % 2
+			},
+			a:            []any{1, 3, 2, 4, 5},
+			expectKeys:   []any{1, 0},
+			expectGroups: [][]any{{1, 3, 5}, {2, 4}},
+		},
+		{
+			name: "groupbystable string",
+			key: func(s any) any {
+				return s.(string)[0]
+			},
+			a:            []any{"banana", "apple", "blueberry", "cherry", "avocado"},
+			expectKeys:   []any{byte('b'), byte('a'), byte('c')},
+			expectGroups: [][]any{{"banana", "blueberry"}, {"apple", "avocado"}, {"cherry"}},
+		},
+		{
+			name: "groupbystable empty slice",
+			key: func(i any) any {
+				return i
+			},
+			a: []any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, groups := groups(iter.GroupByStable(test.key, test.a))
+
+			assert.Equal(t, test.expectKeys, keys)
+			assert.Equal(t, test.expectGroups, groups)
+		})
+	}
+}
+
+func TestIGroupByStable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		key          func(any) any
+		a            stdIter.Seq[any]
+		expectKeys   []any
+		expectGroups [][]any
+	}{
+		{
+			name: "igroupbystable int",
+			key: func(i any) any {
+				return i.(int) % 2
+			},
+			a:            slices.Values([]any{2, 1, 3, 4, 5}),
+			expectKeys:   []any{0, 1},
+			expectGroups: [][]any{{2, 4}, {1, 3, 5}},
+		},
+		{
+			name: "igroupbystable empty sequence",
+			key: func(i any) any {
+				return i
+			},
+			a: slices.Values([]any{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, groups := groups(iter.IGroupByStable(test.key, test.a))
+
+			assert.Equal(t, test.expectKeys, keys)
+			assert.Equal(t, test.expectGroups, groups)
+		})
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		key    func(string) int
+		a      []string
+		expect map[int]int
+	}{
+		{
+			name:   "countby length",
+			key:    func(s string) int { return len(s) },
+			a:      []string{"a", "bb", "cc", "d", "eee"},
+			expect: map[int]int{1: 2, 2: 2, 3: 1},
+		},
+		{
+			name:   "countby empty sequence",
+			key:    func(s string) int { return len(s) },
+			expect: map[int]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.CountBy(test.key, slices.Values(test.a)))
+		})
+	}
+}
+
+func TestSumBy(t *testing.T) {
+	t.Parallel()
+
+	identity := func(i int) int { return i }
+
+	tests := []struct {
+		name   string
+		key    func(int) bool
+		a      []int
+		expect map[bool]int
+	}{
+		{
+			name:   "sumby parity",
+			key:    func(i int) bool { return i%2 == 0 },
+			a:      []int{1, 2, 3, 4, 5},
+			expect: map[bool]int{false: 9, true: 6},
+		},
+		{
+			name:   "sumby single key",
+			key:    func(int) bool { return true },
+			a:      []int{-1, 1, 3},
+			expect: map[bool]int{true: 3},
+		},
+		{
+			name:   "sumby empty sequence",
+			key:    func(int) bool { return true },
+			expect: map[bool]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.SumBy(test.key, identity, slices.Values(test.a)))
 		})
 	}
+
+	t.Run("sumby struct field", func(t *testing.T) {
+		t.Parallel()
+
+		type order struct {
+			customer string
+			amount   float64
+		}
+
+		got := iter.SumBy(
+			func(o order) string { return o.customer },
+			func(o order) float64 { return o.amount },
+			slices.Values([]order{{"alice", 1.5}, {"bob", 2}, {"alice", 3}}),
+		)
+
+		assert.Equal(t, map[string]float64{"alice": 4.5, "bob": 2}, got)
+	})
+}
+
+func TestAggregateBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("aggregateby max", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.AggregateBy(
+			func(s string) byte { return s[0] },
+			func(acc int, s string) int { return max(acc, len(s)) },
+			0,
+			slices.Values([]string{"apple", "banana", "avocado", "blueberry", "cherry"}),
+		)
+
+		assert.Equal(t, map[byte]int{'a': 7, 'b': 9, 'c': 6}, got)
+	})
+
+	t.Run("aggregateby initial value", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.AggregateBy(
+			func(i int) int { return i % 3 },
+			func(acc string, i int) string { return acc + strconv.Itoa(i) },
+			"#",
+			slices.Values([]int{1, 2, 3, 4, 5, 6}),
+		)
+
+		assert.Equal(t, map[int]string{0: "#36", 1: "#14", 2: "#25"}, got)
+	})
+
+	t.Run("aggregateby empty sequence", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.AggregateBy(
+			func(i int) int { return i },
+			func(acc, i int) int { return acc + i },
+			0,
+			slices.Values([]int{}),
+		)
+
+		assert.Empty(t, got)
+	})
 }
 
 func TestMap(t *testing.T) {
@@ -1042,6 +1791,51 @@ func TestTakeWhile(t *testing.T) {
 	}
 }
 
+func TestITakeWhile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(any) bool
+		a      stdIter.Seq[any]
+		expect []any
+	}{
+		{
+			name: "itake while int",
+			pred: func(i any) bool {
+				return i.(int) < 3
+			},
+			a:      slices.Values([]any{1, 2, 3, 4, 1}),
+			expect: []any{1, 2},
+		},
+		{
+			name: "itake while string",
+			pred: func(s any) bool {
+				return s.(string) != "c"
+			},
+			a:      slices.Values([]any{"a", "b", "c", "d", "e", "c"}),
+			expect: []any{"a", "b"},
+		},
+		{
+			name: "itake while always true",
+			pred: func(any) bool {
+				return true
+			},
+			a:      slices.Values([]any{1, 2, 3}),
+			expect: []any{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.ITakeWhile(test.pred, test.a))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
 func TestChainMap(t *testing.T) {
 	t.Parallel()
 
@@ -1110,7 +1904,7 @@ func TestPermutation(t *testing.T) {
 		{
 			name:   "permutation of 0 elements",
 			a:      []any{},
-			expect: []any{},
+			expect: []any{[]any{}},
 		},
 		{
 			name:   "permutation of 1 elements",
@@ -1130,10 +1924,10 @@ func TestPermutation(t *testing.T) {
 			a:    []any{1, 2, 3},
 			expect: []any{
 				[]any{1, 2, 3},
-				[]any{2, 1, 3},
-				[]any{3, 1, 2},
 				[]any{1, 3, 2},
+				[]any{2, 1, 3},
 				[]any{2, 3, 1},
+				[]any{3, 1, 2},
 				[]any{3, 2, 1},
 			},
 		},
@@ -1142,29 +1936,41 @@ func TestPermutation(t *testing.T) {
 			a:    []any{1, 2, 3, 4},
 			expect: []any{
 				[]any{1, 2, 3, 4},
-				[]any{2, 1, 3, 4},
-				[]any{3, 1, 2, 4},
-				[]any{1, 3, 2, 4},
-				[]any{2, 3, 1, 4},
-				[]any{3, 2, 1, 4},
-				[]any{4, 2, 1, 3},
-				[]any{2, 4, 1, 3},
-				[]any{1, 4, 2, 3},
-				[]any{4, 1, 2, 3},
-				[]any{2, 1, 4, 3},
 				[]any{1, 2, 4, 3},
+				[]any{1, 3, 2, 4},
 				[]any{1, 3, 4, 2},
-				[]any{3, 1, 4, 2},
-				[]any{4, 1, 3, 2},
+				[]any{1, 4, 2, 3},
 				[]any{1, 4, 3, 2},
-				[]any{3, 4, 1, 2},
-				[]any{4, 3, 1, 2},
-				[]any{4, 3, 2, 1},
-				[]any{3, 4, 2, 1},
+				[]any{2, 1, 3, 4},
+				[]any{2, 1, 4, 3},
+				[]any{2, 3, 1, 4},
+				[]any{2, 3, 4, 1},
+				[]any{2, 4, 1, 3},
 				[]any{2, 4, 3, 1},
-				[]any{4, 2, 3, 1},
+				[]any{3, 1, 2, 4},
+				[]any{3, 1, 4, 2},
+				[]any{3, 2, 1, 4},
 				[]any{3, 2, 4, 1},
-				[]any{2, 3, 4, 1},
+				[]any{3, 4, 1, 2},
+				[]any{3, 4, 2, 1},
+				[]any{4, 1, 2, 3},
+				[]any{4, 1, 3, 2},
+				[]any{4, 2, 1, 3},
+				[]any{4, 2, 3, 1},
+				[]any{4, 3, 1, 2},
+				[]any{4, 3, 2, 1},
+			},
+		},
+		{
+			name: "permutation with duplicated values",
+			a:    []any{1, 1, 2},
+			expect: []any{
+				[]any{1, 1, 2},
+				[]any{1, 2, 1},
+				[]any{1, 1, 2},
+				[]any{1, 2, 1},
+				[]any{2, 1, 1},
+				[]any{2, 1, 1},
 			},
 		},
 	}
@@ -1177,6 +1983,7 @@ func TestPermutation(t *testing.T) {
 
 			for got := range iter.Permutations(test.a) {
 				t.Logf("%d: got: %v", i, got)
+				require.Less(t, i, len(test.expect))
 				assert.Equal(t, test.expect[i], got)
 
 				i++
@@ -1200,7 +2007,7 @@ func TestPermutationLen(t *testing.T) {
 			name:   "permutation of 3 elements, len = 0",
 			a:      []any{1, 2, 3},
 			len:    0,
-			expect: []any{},
+			expect: []any{[]any{}},
 		},
 		{
 			name: "permutation of 3 elements, len = 1",
@@ -1218,11 +2025,30 @@ func TestPermutationLen(t *testing.T) {
 			len:  2,
 			expect: []any{
 				[]any{1, 2},
+				[]any{1, 3},
 				[]any{2, 1},
+				[]any{2, 3},
 				[]any{3, 1},
+				[]any{3, 2},
+			},
+		},
+		{
+			name: "permutation of 4 elements, len = 2",
+			a:    []any{1, 2, 3, 4},
+			len:  2,
+			expect: []any{
+				[]any{1, 2},
 				[]any{1, 3},
+				[]any{1, 4},
+				[]any{2, 1},
 				[]any{2, 3},
+				[]any{2, 4},
+				[]any{3, 1},
 				[]any{3, 2},
+				[]any{3, 4},
+				[]any{4, 1},
+				[]any{4, 2},
+				[]any{4, 3},
 			},
 		},
 		{
@@ -1231,20 +2057,24 @@ func TestPermutationLen(t *testing.T) {
 			len:  3,
 			expect: []any{
 				[]any{1, 2, 3},
-				[]any{2, 1, 3},
-				[]any{3, 1, 2},
 				[]any{1, 3, 2},
+				[]any{2, 1, 3},
 				[]any{2, 3, 1},
+				[]any{3, 1, 2},
 				[]any{3, 2, 1},
 			},
 		},
 		{
-			name: "len(a) < len",
-			a:    []any{1, 2, 3},
-			len:  42,
-			expect: []any{
-				[]any{1, 2, 3},
-			},
+			name:   "len(a) < len",
+			a:      []any{1, 2, 3},
+			len:    42,
+			expect: []any{},
+		},
+		{
+			name:   "len < 0",
+			a:      []any{1, 2, 3},
+			len:    -1,
+			expect: []any{},
 		},
 	}
 
@@ -1256,6 +2086,18 @@ func TestPermutationLen(t *testing.T) {
 
 			for got := range iter.PermutationsLen(test.a, test.len) {
 				t.Logf("got: %v", got)
+				require.Less(t, i, len(test.expect))
+				assert.Equal(t, test.expect[i], got)
+
+				i++
+			}
+
+			assert.Equal(t, len(test.expect), i)
+
+			i = 0
+
+			for got := range iter.PermutationsLenShared(test.a, test.len) {
+				require.Less(t, i, len(test.expect))
 				assert.Equal(t, test.expect[i], got)
 
 				i++
@@ -1264,6 +2106,209 @@ func TestPermutationLen(t *testing.T) {
 			assert.Equal(t, len(test.expect), i)
 		})
 	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		t.Parallel()
+
+		a := []int{1, 2, 3, 4}
+		for range iter.PermutationsLen(a, 3) {
+		}
+
+		assert.Equal(t, []int{1, 2, 3, 4}, a)
+	})
+
+	t.Run("sequence can be ranged over twice", func(t *testing.T) {
+		t.Parallel()
+
+		seq := iter.PermutationsLen([]int{1, 2, 3}, 2)
+		assert.Equal(t, iter.Values(seq), iter.Values(seq))
+		assert.Len(t, iter.Values(seq), 6)
+	})
+
+	t.Run("permutations are not shared", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.PermutationsLen([]int{1, 2}, 2))
+		assert.Equal(t, [][]int{{1, 2}, {2, 1}}, got)
+	})
+
+	t.Run("shared permutations reuse the buffer", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.PermutationsShared([]int{1, 2, 3}))
+		require.Len(t, got, 6)
+		assert.Same(t, &got[0][0], &got[5][0])
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []any
+		r      int
+		expect [][]any
+	}{
+		{
+			name:   "combinations of 4 elements, r = 2",
+			a:      []any{1, 2, 3, 4},
+			r:      2,
+			expect: [][]any{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name:   "combinations of 4 elements, r = 3",
+			a:      []any{"a", "b", "c", "d"},
+			r:      3,
+			expect: [][]any{{"a", "b", "c"}, {"a", "b", "d"}, {"a", "c", "d"}, {"b", "c", "d"}},
+		},
+		{
+			name:   "combinations of 3 elements, r = 3",
+			a:      []any{1, 2, 3},
+			r:      3,
+			expect: [][]any{{1, 2, 3}},
+		},
+		{
+			name:   "combinations with duplicated values",
+			a:      []any{1, 1, 2},
+			r:      2,
+			expect: [][]any{{1, 1}, {1, 2}, {1, 2}},
+		},
+		{
+			name:   "combinations r = 0",
+			a:      []any{1, 2, 3},
+			r:      0,
+			expect: [][]any{{}},
+		},
+		{
+			name:   "combinations r > len(a)",
+			a:      []any{1, 2, 3},
+			r:      4,
+			expect: nil,
+		},
+		{
+			name:   "combinations r < 0",
+			a:      []any{1, 2, 3},
+			r:      -1,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Combinations(test.a, test.r))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []any
+		r      int
+		expect [][]any
+	}{
+		{
+			name:   "combinations with replacement of 3 elements, r = 2",
+			a:      []any{1, 2, 3},
+			r:      2,
+			expect: [][]any{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}},
+		},
+		{
+			name:   "combinations with replacement r > len(a)",
+			a:      []any{"a", "b"},
+			r:      3,
+			expect: [][]any{{"a", "a", "a"}, {"a", "a", "b"}, {"a", "b", "b"}, {"b", "b", "b"}},
+		},
+		{
+			name:   "combinations with replacement r = 0",
+			a:      []any{1, 2},
+			r:      0,
+			expect: [][]any{{}},
+		},
+		{
+			name:   "combinations with replacement of 0 elements",
+			a:      []any{},
+			r:      2,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.CombinationsWithReplacement(test.a, test.r))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]any
+		expect [][]any
+	}{
+		{
+			name:   "product of two slices",
+			a:      [][]any{{1, 2}, {"a", "b", "c"}},
+			expect: [][]any{{1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "b"}, {2, "c"}},
+		},
+		{
+			name:   "product of three slices",
+			a:      [][]any{{1, 2}, {3}, {4, 5}},
+			expect: [][]any{{1, 3, 4}, {1, 3, 5}, {2, 3, 4}, {2, 3, 5}},
+		},
+		{
+			name:   "product of one slice",
+			a:      [][]any{{1, 2}},
+			expect: [][]any{{1}, {2}},
+		},
+		{
+			name:   "product with an empty slice",
+			a:      [][]any{{1, 2}, {}},
+			expect: nil,
+		},
+		{
+			name:   "product of no slice",
+			a:      nil,
+			expect: [][]any{{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Product(test.a...))
+			assert.Equal(t, test.expect, got)
+
+			seqs := make([]stdIter.Seq[any], len(test.a))
+			for i := range test.a {
+				seqs[i] = slices.Values(test.a[i])
+			}
+
+			got = iter.Values(iter.IProduct(seqs...))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+
+	t.Run("products are not shared", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.Product([]int{1, 2}, []int{3}))
+		got[0][0] = 42
+
+		assert.Equal(t, [][]int{{42, 3}, {2, 3}}, got)
+	})
 }
 
 func TestAppend(t *testing.T) {
@@ -1321,44 +2366,118 @@ func TestSecond(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
-func TestEqual(t *testing.T) {
+func TestFilter2(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name   string
-		a      stdIter.Seq[any]
-		b      stdIter.Seq[any]
-		expect bool
+		name    string
+		pred    func(any, any) bool
+		a       stdIter.Seq2[any, any]
+		expectA []any
+		expectB []any
 	}{
 		{
-			name:   "equal two slices of int",
-			a:      iter.Chain([]any{1, 2, 3}),
-			b:      iter.Chain([]any{1, 2, 3}),
-			expect: true,
+			name: "filter2 on key",
+			pred: func(a, _ any) bool {
+				return a.(int)%2 == 1
+			},
+			a:       iter.Zip([]any{1, 2, 3}, []any{"a", "b", "c"}),
+			expectA: []any{1, 3},
+			expectB: []any{"a", "c"},
 		},
 		{
-			name:   "two slices of int, len(a) > len(b)",
-			a:      iter.Chain([]any{1, 2, 3}),
-			b:      iter.Chain([]any{1, 2}),
-			expect: true,
+			name: "filter2 on value",
+			pred: func(_, b any) bool {
+				return b.(string) == "b"
+			},
+			a:       iter.Zip([]any{1, 2, 3}, []any{"a", "b", "c"}),
+			expectA: []any{2},
+			expectB: []any{"b"},
 		},
 		{
-			name:   "two slices of int, len(a) < len(b)",
-			a:      iter.Chain([]any{1, 2}),
-			b:      iter.Chain([]any{1, 2, 3}),
-			expect: true,
+			name: "filter2 always false",
+			pred: func(_, _ any) bool {
+				return false
+			},
+			a:       iter.Zip([]any{1, 2, 3}, []any{"a", "b", "c"}),
+			expectA: nil,
+			expectB: nil,
 		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotA, gotB := iter.Values2(iter.Filter2(test.pred, test.a))
+			assert.Equal(t, test.expectA, gotA)
+			assert.Equal(t, test.expectB, gotB)
+		})
+	}
+}
+
+func TestMapPairs(t *testing.T) {
+	t.Parallel()
+
+	got := iter.Values2Map(iter.MapPairs(
+		func(k int, v string) (string, int) { return v + v, k * 10 },
+		iter.Zip([]int{1, 2, 3}, []string{"a", "b", "c"}),
+	))
+
+	assert.Equal(t, map[string]int{"aa": 10, "bb": 20, "cc": 30}, got)
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	gotA, gotB := iter.Values2(iter.MapKeys(
+		func(k int) int { return k * 2 },
+		iter.Zip([]int{1, 2, 3}, []string{"a", "b", "c"}),
+	))
+
+	assert.Equal(t, []int{2, 4, 6}, gotA)
+	assert.Equal(t, []string{"a", "b", "c"}, gotB)
+}
+
+func TestMapValues(t *testing.T) {
+	t.Parallel()
+
+	gotA, gotB := iter.Values2(iter.MapValues(
+		func(v string) string { return v + "!" },
+		iter.Zip([]int{1, 2, 3}, []string{"a", "b", "c"}),
+	))
+
+	assert.Equal(t, []int{1, 2, 3}, gotA)
+	assert.Equal(t, []string{"a!", "b!", "c!"}, gotB)
+}
+
+func TestTakeWhile2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pred    func(any, any) bool
+		a       stdIter.Seq2[any, any]
+		expectA []any
+		expectB []any
+	}{
 		{
-			name:   "not equal two slices of int",
-			a:      iter.Chain([]any{1, 2, 5}),
-			b:      iter.Chain([]any{1, 2, 3}),
-			expect: false,
+			name: "take while2 int",
+			pred: func(a, b any) bool {
+				return a.(int) < b.(int)
+			},
+			a:       iter.Zip([]any{1, 2, 3, 1}, []any{3, 3, 3, 3}),
+			expectA: []any{1, 2},
+			expectB: []any{3, 3},
 		},
 		{
-			name:   "not equal two slices of int, len(a) > len(b)",
-			a:      iter.Chain([]any{1, 2, 5}),
-			b:      iter.Chain([]any{1, 2}),
-			expect: true,
+			name: "take while2 always true",
+			pred: func(_, _ any) bool {
+				return true
+			},
+			a:       iter.Zip([]any{1, 2}, []any{"a", "b"}),
+			expectA: []any{1, 2},
+			expectB: []any{"a", "b"},
 		},
 	}
 
@@ -1366,7 +2485,1507 @@ func TestEqual(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, test.expect, iter.Equal(test.a, test.b))
+			gotA, gotB := iter.Values2(iter.TakeWhile2(test.pred, test.a))
+			assert.Equal(t, test.expectA, gotA)
+			assert.Equal(t, test.expectB, gotB)
+		})
+	}
+}
+
+func TestDropWhile2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pred    func(any, any) bool
+		a       stdIter.Seq2[any, any]
+		expectA []any
+		expectB []any
+	}{
+		{
+			name: "drop while2 int",
+			pred: func(a, b any) bool {
+				return a.(int) < b.(int)
+			},
+			a:       iter.Zip([]any{1, 2, 3, 1}, []any{3, 3, 3, 3}),
+			expectA: []any{3, 1},
+			expectB: []any{3, 3},
+		},
+		{
+			name: "drop while2 always true",
+			pred: func(_, _ any) bool {
+				return true
+			},
+			a:       iter.Zip([]any{1, 2}, []any{"a", "b"}),
+			expectA: nil,
+			expectB: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotA, gotB := iter.Values2(iter.DropWhile2(test.pred, test.a))
+			assert.Equal(t, test.expectA, gotA)
+			assert.Equal(t, test.expectB, gotB)
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	t.Parallel()
+
+	gotA, gotB := iter.Values2(iter.Swap(iter.Zip([]int{1, 2, 3}, []string{"a", "b", "c"})))
+
+	assert.Equal(t, []string{"a", "b", "c"}, gotA)
+	assert.Equal(t, []int{1, 2, 3}, gotB)
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		n      int
+		expect []any
+	}{
+		{
+			name:   "take less than length",
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			n:      2,
+			expect: []any{1, 2},
+		},
+		{
+			name:   "take more than length",
+			a:      slices.Values([]any{1, 2, 3}),
+			n:      42,
+			expect: []any{1, 2, 3},
+		},
+		{
+			name:   "take zero",
+			a:      slices.Values([]any{1, 2, 3}),
+			n:      0,
+			expect: nil,
+		},
+		{
+			name:   "take negative",
+			a:      slices.Values([]any{1, 2, 3}),
+			n:      -1,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Take(test.a, test.n))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		n      int
+		expect []any
+	}{
+		{
+			name:   "skip less than length",
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			n:      2,
+			expect: []any{3, 4, 5},
+		},
+		{
+			name:   "skip more than length",
+			a:      slices.Values([]any{1, 2, 3}),
+			n:      42,
+			expect: nil,
+		},
+		{
+			name:   "skip zero",
+			a:      slices.Values([]any{1, 2, 3}),
+			n:      0,
+			expect: []any{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Skip(test.a, test.n))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestTake2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		n       int
+		expectK []string
+		expectV []int
+	}{
+		{
+			name:    "take2 less than length",
+			n:       2,
+			expectK: []string{"a", "b"},
+			expectV: []int{1, 2},
+		},
+		{
+			name:    "take2 more than length",
+			n:       42,
+			expectK: []string{"a", "b", "c"},
+			expectV: []int{1, 2, 3},
+		},
+		{
+			name: "take2 zero",
+			n:    0,
+		},
+		{
+			name: "take2 negative",
+			n:    -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, values := iter.Values2(iter.Take2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), test.n))
+
+			assert.Equal(t, test.expectK, keys)
+			assert.Equal(t, test.expectV, values)
+		})
+	}
+}
+
+func TestSkip2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		n       int
+		expectK []string
+		expectV []int
+	}{
+		{
+			name:    "skip2 less than length",
+			n:       2,
+			expectK: []string{"c"},
+			expectV: []int{3},
+		},
+		{
+			name: "skip2 more than length",
+			n:    42,
+		},
+		{
+			name:    "skip2 zero",
+			n:       0,
+			expectK: []string{"a", "b", "c"},
+			expectV: []int{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, values := iter.Values2(iter.Skip2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), test.n))
+
+			assert.Equal(t, test.expectK, keys)
+			assert.Equal(t, test.expectV, values)
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		start  int
+		stop   int
+		step   int
+		expect []int
+	}{
+		{
+			name:   "slice start stop",
+			start:  2,
+			stop:   5,
+			step:   1,
+			expect: []int{2, 3, 4},
+		},
+		{
+			name:   "slice unbounded",
+			start:  6,
+			stop:   -1,
+			step:   1,
+			expect: []int{6, 7, 8, 9},
+		},
+		{
+			name:   "slice with step",
+			start:  1,
+			stop:   8,
+			step:   3,
+			expect: []int{1, 4, 7},
+		},
+		{
+			name:   "slice unbounded with step",
+			start:  0,
+			stop:   -1,
+			step:   4,
+			expect: []int{0, 4, 8},
+		},
+		{
+			name:   "slice stop past the end",
+			start:  8,
+			stop:   42,
+			step:   1,
+			expect: []int{8, 9},
+		},
+		{
+			name:   "slice start past the end",
+			start:  42,
+			stop:   -1,
+			step:   1,
+			expect: nil,
+		},
+		{
+			name:   "slice stop before start",
+			start:  5,
+			stop:   2,
+			step:   1,
+			expect: nil,
+		},
+		{
+			name:   "slice empty",
+			start:  0,
+			stop:   0,
+			step:   1,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.Slice(iter.Take(iter.Count(0, 1), 10), test.start, test.stop, test.step)))
+		})
+	}
+
+	t.Run("slice does not consume past stop", func(t *testing.T) {
+		t.Parallel()
+
+		var read int
+
+		seq := iter.From(iter.Count(0, 1)).Peek(func(int) { read++ }).Seq()
+
+		assert.Equal(t, []int{1, 2}, iter.Values(iter.Slice(seq, 1, 3, 1)))
+		assert.Equal(t, 3, read)
+	})
+
+	t.Run("slice negative start", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "iter: start cannot be negative", func() {
+			iter.Slice(slices.Values(ints(10)), -1, -1, 1)
+		})
+	})
+
+	t.Run("slice step less than 1", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "iter: step cannot be less than 1", func() {
+			iter.Slice(slices.Values(ints(10)), 0, -1, 0)
+		})
+	})
+}
+
+func TestSlice2(t *testing.T) {
+	t.Parallel()
+
+	keys, values := iter.Values2(iter.Slice2(iter.Zip([]string{"a", "b", "c", "d", "e"}, ints(5)), 1, -1, 2))
+
+	assert.Equal(t, []string{"b", "d"}, keys)
+	assert.Equal(t, []int{2, 4}, values)
+}
+
+func TestTakeLast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		n      int
+		expect []int
+	}{
+		{
+			name:   "take last less than length",
+			a:      []int{1, 2, 3, 4, 5},
+			n:      2,
+			expect: []int{4, 5},
+		},
+		{
+			name:   "take last wrapping the buffer",
+			a:      []int{1, 2, 3, 4, 5, 6, 7},
+			n:      3,
+			expect: []int{5, 6, 7},
+		},
+		{
+			name:   "take last more than length",
+			a:      []int{1, 2, 3},
+			n:      42,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "take last very large n",
+			a:      []int{1, 2, 3},
+			n:      math.MaxInt,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "take last length",
+			a:      []int{1, 2, 3},
+			n:      3,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "take last zero",
+			a:      []int{1, 2, 3},
+			n:      0,
+			expect: nil,
+		},
+		{
+			name:   "take last negative",
+			a:      []int{1, 2, 3},
+			n:      -1,
+			expect: nil,
+		},
+		{
+			name:   "take last empty",
+			n:      2,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.TakeLast(slices.Values(test.a), test.n)))
+		})
+	}
+}
+
+func TestTakeLast2(t *testing.T) {
+	t.Parallel()
+
+	keys, values := iter.Values2(iter.TakeLast2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 2))
+
+	assert.Equal(t, []string{"b", "c"}, keys)
+	assert.Equal(t, []int{2, 3}, values)
+}
+
+func TestSkipLast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		n      int
+		expect []int
+	}{
+		{
+			name:   "skip last less than length",
+			a:      []int{1, 2, 3, 4, 5},
+			n:      2,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "skip last wrapping the buffer",
+			a:      []int{1, 2, 3, 4, 5, 6, 7},
+			n:      3,
+			expect: []int{1, 2, 3, 4},
+		},
+		{
+			name:   "skip last more than length",
+			a:      []int{1, 2, 3},
+			n:      42,
+			expect: nil,
+		},
+		{
+			name:   "skip last very large n",
+			a:      []int{1, 2, 3},
+			n:      math.MaxInt,
+			expect: nil,
+		},
+		{
+			name:   "skip last zero",
+			a:      []int{1, 2, 3},
+			n:      0,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "skip last negative",
+			a:      []int{1, 2, 3},
+			n:      -1,
+			expect: []int{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.SkipLast(slices.Values(test.a), test.n)))
+		})
+	}
+
+	t.Run("skip last unbounded sequence", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []int{0, 1, 2}, iter.Values(iter.Take(iter.SkipLast(iter.Count(0, 1), 5), 3)))
+	})
+}
+
+func TestSkipLast2(t *testing.T) {
+	t.Parallel()
+
+	keys, values := iter.Values2(iter.SkipLast2(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}), 2))
+
+	assert.Equal(t, []string{"a"}, keys)
+	assert.Equal(t, []int{1}, values)
+}
+
+func TestEnumerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		a       []string
+		expectI []int
+		expectV []string
+	}{
+		{
+			name:    "enumerate",
+			a:       []string{"a", "b", "c"},
+			expectI: []int{0, 1, 2},
+			expectV: []string{"a", "b", "c"},
+		},
+		{
+			name: "enumerate empty sequence",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			indices, values := iter.Values2(iter.Enumerate(slices.Values(test.a)))
+
+			assert.Equal(t, test.expectI, indices)
+			assert.Equal(t, test.expectV, values)
+		})
+	}
+}
+
+func TestEnumerateFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		start   int
+		expectI []int
+	}{
+		{
+			name:    "enumerate from one",
+			start:   1,
+			expectI: []int{1, 2, 3},
+		},
+		{
+			name:    "enumerate from negative",
+			start:   -1,
+			expectI: []int{-1, 0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			indices, values := iter.Values2(iter.EnumerateFrom(slices.Values([]string{"a", "b", "c"}), test.start))
+
+			assert.Equal(t, test.expectI, indices)
+			assert.Equal(t, []string{"a", "b", "c"}, values)
+		})
+	}
+}
+
+func TestFilterIndexed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(int, string) bool
+		a      []string
+		expect []string
+	}{
+		{
+			name:   "filter indexed even indices",
+			pred:   func(i int, _ string) bool { return i%2 == 0 },
+			a:      []string{"a", "b", "c", "d", "e"},
+			expect: []string{"a", "c", "e"},
+		},
+		{
+			name:   "filter indexed index and value",
+			pred:   func(i int, s string) bool { return i > 0 && s != "c" },
+			a:      []string{"a", "b", "c", "d"},
+			expect: []string{"b", "d"},
+		},
+		{
+			name:   "filter indexed empty sequence",
+			pred:   func(int, string) bool { return true },
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.FilterIndexed(test.pred, slices.Values(test.a))))
+		})
+	}
+}
+
+func TestMapIndexed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []string
+		expect []string
+	}{
+		{
+			name:   "map indexed",
+			a:      []string{"a", "b", "c"},
+			expect: []string{"0:a", "1:b", "2:c"},
+		},
+		{
+			name:   "map indexed empty sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.MapIndexed(func(i int, s string) string { return strconv.Itoa(i) + ":" + s }, slices.Values(test.a))
+
+			assert.Equal(t, test.expect, iter.Values(got))
+		})
+	}
+}
+
+func TestTakeWhileIndexed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(int, int) bool
+		a      []int
+		expect []int
+	}{
+		{
+			name:   "take while indexed by index",
+			pred:   func(i, _ int) bool { return i < 2 },
+			a:      []int{5, 6, 7, 8},
+			expect: []int{5, 6},
+		},
+		{
+			name:   "take while indexed value equals index",
+			pred:   func(i, v int) bool { return i == v },
+			a:      []int{0, 1, 2, 4, 4},
+			expect: []int{0, 1, 2},
+		},
+		{
+			name:   "take while indexed always true",
+			pred:   func(int, int) bool { return true },
+			a:      []int{1, 2},
+			expect: []int{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.TakeWhileIndexed(test.pred, slices.Values(test.a))))
+		})
+	}
+}
+
+func TestDropWhileIndexed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pred   func(int, int) bool
+		a      []int
+		expect []int
+	}{
+		{
+			name:   "drop while indexed by index",
+			pred:   func(i, _ int) bool { return i < 2 },
+			a:      []int{5, 6, 7, 8},
+			expect: []int{7, 8},
+		},
+		{
+			name:   "drop while indexed value equals index",
+			pred:   func(i, v int) bool { return i == v },
+			a:      []int{0, 1, 2, 4, 4},
+			expect: []int{4, 4},
+		},
+		{
+			name:   "drop while indexed always true",
+			pred:   func(int, int) bool { return true },
+			a:      []int{1, 2},
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.DropWhileIndexed(test.pred, slices.Values(test.a))))
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]int
+		expect []int
+	}{
+		{
+			name:   "flatten",
+			a:      [][]int{{1, 2}, {3}, {4, 5}},
+			expect: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:   "flatten with empty sequences",
+			a:      [][]int{{}, {1}, {}, {2}, {}},
+			expect: []int{1, 2},
+		},
+		{
+			name:   "flatten empty sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seqs := iter.IMap(slices.Values, slices.Values(test.a))
+
+			assert.Equal(t, test.expect, iter.Values(iter.Flatten(seqs)))
+			assert.Equal(t, test.expect, iter.Values(iter.FlattenSlices(slices.Values(test.a))))
+		})
+	}
+
+	t.Run("flatten permutations", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.FlattenSlices(iter.PermutationsLen([]int{1, 2, 3}, 2)))
+
+		assert.Equal(t, []int{1, 2, 1, 3, 2, 1, 2, 3, 3, 1, 3, 2}, got)
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		f      func(int) stdIter.Seq[int]
+		a      []int
+		expect []int
+	}{
+		{
+			name:   "flatmap repeat",
+			f:      func(i int) stdIter.Seq[int] { return iter.RepeatN(i, i) },
+			a:      []int{1, 2, 3},
+			expect: []int{1, 2, 2, 3, 3, 3},
+		},
+		{
+			name:   "flatmap to empty sequences",
+			f:      func(int) stdIter.Seq[int] { return iter.RepeatN(0, 0) },
+			a:      []int{1, 2, 3},
+			expect: nil,
+		},
+		{
+			name:   "flatmap empty sequence",
+			f:      func(i int) stdIter.Seq[int] { return iter.RepeatN(i, i) },
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.FlatMap(test.f, slices.Values(test.a))))
+		})
+	}
+}
+
+func TestFlatten2(t *testing.T) {
+	t.Parallel()
+
+	keys, values := iter.Values2(iter.Flatten2(iter.GroupBy(func(i int) bool { return i%2 == 0 }, []int{1, 3, 2, 4, 5})))
+
+	assert.Equal(t, []bool{false, false, true, true, false}, keys)
+	assert.Equal(t, []int{1, 3, 2, 4, 5}, values)
+}
+
+func TestIntersperse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []string
+		expect []string
+	}{
+		{
+			name:   "intersperse",
+			a:      []string{"a", "b", "c"},
+			expect: []string{"a", ",", "b", ",", "c"},
+		},
+		{
+			name:   "intersperse single element",
+			a:      []string{"a"},
+			expect: []string{"a"},
+		},
+		{
+			name:   "intersperse empty sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.Intersperse(slices.Values(test.a), ",")))
+		})
+	}
+}
+
+func TestIntersperseFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("intersperse func", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		sep := func() int {
+			calls++
+
+			return -calls
+		}
+
+		assert.Equal(t, []int{1, -1, 2, -2, 3}, iter.Values(iter.IntersperseFunc(slices.Values([]int{1, 2, 3}), sep)))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("intersperse func is lazy", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		sep := func() int {
+			calls++
+
+			return 0
+		}
+
+		for range iter.IntersperseFunc(slices.Values([]int{1, 2, 3}), sep) {
+			break
+		}
+
+		assert.Zero(t, calls)
+	})
+}
+
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		expect []any
+	}{
+		{
+			name:   "distinct int",
+			a:      slices.Values([]any{1, 2, 1, 3, 2, 4}),
+			expect: []any{1, 2, 3, 4},
+		},
+		{
+			name:   "distinct mixed types",
+			a:      slices.Values([]any{1, "1", 1, '1', "1"}),
+			expect: []any{1, "1", '1'},
+		},
+		{
+			name:   "distinct empty sequence",
+			a:      slices.Values([]any{}),
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Distinct(test.a))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		n      int
+		expect [][]any
+	}{
+		{
+			name:   "chunk with a shorter last chunk",
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			n:      2,
+			expect: [][]any{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:   "chunk of exact size",
+			a:      slices.Values([]any{1, 2, 3, 4}),
+			n:      2,
+			expect: [][]any{{1, 2}, {3, 4}},
+		},
+		{
+			name:   "chunk larger than the sequence",
+			a:      slices.Values([]any{1, 2}),
+			n:      3,
+			expect: [][]any{{1, 2}},
+		},
+		{
+			name:   "chunk empty sequence",
+			a:      slices.Values([]any{}),
+			n:      3,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Chunk(test.a, test.n))
+			assert.Equal(t, test.expect, got)
+		})
+	}
+
+	t.Run("chunk of size 0 panics", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "iter: n cannot be less than 1", func() {
+			iter.Chunk(slices.Values([]int{1}), 0)
+		})
+	})
+
+	t.Run("chunk of a very large size", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.Chunk(slices.Values([]int{1, 2, 3}), math.MaxInt))
+
+		assert.Equal(t, [][]int{{1, 2, 3}}, got)
+	})
+}
+
+func TestWindows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		size   int
+		step   int
+		expect [][]any
+	}{
+		{
+			name:   "sliding windows",
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			size:   3,
+			step:   1,
+			expect: [][]any{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+		{
+			name:   "stepped windows",
+			a:      slices.Values([]any{1, 2, 3, 4, 5, 6}),
+			size:   3,
+			step:   2,
+			expect: [][]any{{1, 2, 3}, {3, 4, 5}},
+		},
+		{
+			name:   "tumbling windows",
+			a:      slices.Values([]any{1, 2, 3, 4, 5}),
+			size:   2,
+			step:   2,
+			expect: [][]any{{1, 2}, {3, 4}},
+		},
+		{
+			name:   "windows with gaps",
+			a:      slices.Values([]any{1, 2, 3, 4, 5, 6, 7, 8}),
+			size:   2,
+			step:   3,
+			expect: [][]any{{1, 2}, {4, 5}, {7, 8}},
+		},
+		{
+			name:   "window larger than the sequence",
+			a:      slices.Values([]any{1, 2}),
+			size:   3,
+			step:   1,
+			expect: nil,
+		},
+		{
+			name:   "window of a very large size",
+			a:      slices.Values([]any{1, 2, 3}),
+			size:   math.MaxInt,
+			step:   1,
+			expect: nil,
+		},
+		{
+			name:   "window of size 1",
+			a:      slices.Values([]any{"a", "b"}),
+			size:   1,
+			step:   1,
+			expect: [][]any{{"a"}, {"b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Values(iter.Windows(test.a, test.size, test.step))
+			assert.Equal(t, test.expect, got)
+
+			var gotShared [][]any
+			for window := range iter.WindowsShared(test.a, test.size, test.step) {
+				gotShared = append(gotShared, slices.Clone(window))
+			}
+
+			assert.Equal(t, test.expect, gotShared)
+		})
+	}
+
+	t.Run("invalid arguments panic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() { iter.Windows(slices.Values([]int{1}), 0, 1) })
+		assert.Panics(t, func() { iter.Windows(slices.Values([]int{1}), 1, 0) })
+	})
+
+	t.Run("windows are not shared", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.Window(slices.Values([]int{1, 2, 3, 4}), 2))
+		got[0][1] = 42
+
+		assert.Equal(t, [][]int{{1, 42}, {2, 3}, {3, 4}}, got)
+	})
+
+	t.Run("shared windows reuse the buffer", func(t *testing.T) {
+		t.Parallel()
+
+		got := iter.Values(iter.WindowsShared(slices.Values([]int{1, 2, 3, 4}), 2, 1))
+		require.Len(t, got, 3)
+		assert.Same(t, &got[0][0], &got[1][0])
+		assert.Same(t, &got[0][0], &got[2][0])
+	})
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	got := iter.Values(iter.Window(slices.Values([]int{1, 2, 3, 4}), 2))
+	assert.Equal(t, [][]int{{1, 2}, {2, 3}, {3, 4}}, got)
+}
+
+func TestTumbling(t *testing.T) {
+	t.Parallel()
+
+	got := iter.Values(iter.Tumbling(slices.Values([]int{1, 2, 3, 4, 5, 6, 7}), 3))
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}}, got)
+}
+
+func TestPairwise(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		a       stdIter.Seq[any]
+		expectA []any
+		expectB []any
+	}{
+		{
+			name:    "pairwise int",
+			a:       slices.Values([]any{1, 2, 3, 4}),
+			expectA: []any{1, 2, 3},
+			expectB: []any{2, 3, 4},
+		},
+		{
+			name:    "pairwise single element",
+			a:       slices.Values([]any{1}),
+			expectA: nil,
+			expectB: nil,
+		},
+		{
+			name:    "pairwise empty sequence",
+			a:       slices.Values([]any{}),
+			expectA: nil,
+			expectB: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotA, gotB := iter.Values2(iter.Pairwise(test.a))
+			assert.Equal(t, test.expectA, gotA)
+			assert.Equal(t, test.expectB, gotB)
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		f        func(any, any) any
+		a        stdIter.Seq[any]
+		expect   any
+		expectOk bool
+	}{
+		{
+			name: "reduce sum",
+			f: func(a, b any) any {
+				return a.(int) + b.(int)
+			},
+			a:        slices.Values([]any{1, 2, 3, 4}),
+			expect:   10,
+			expectOk: true,
+		},
+		{
+			name: "reduce max",
+			f: func(a, b any) any {
+				return max(a.(int), b.(int))
+			},
+			a:        slices.Values([]any{3, 7, 2}),
+			expect:   7,
+			expectOk: true,
+		},
+		{
+			name: "reduce single element",
+			f: func(_, _ any) any {
+				return nil
+			},
+			a:        slices.Values([]any{"a"}),
+			expect:   "a",
+			expectOk: true,
+		},
+		{
+			name: "reduce empty sequence",
+			f: func(_, _ any) any {
+				return nil
+			},
+			a:        slices.Values([]any{}),
+			expect:   nil,
+			expectOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := iter.Reduce(test.f, test.a)
+			assert.Equal(t, test.expectOk, ok)
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(any, any) any
+		initial any
+		a       stdIter.Seq[any]
+		expect  any
+	}{
+		{
+			name: "fold sum",
+			f: func(acc, elem any) any {
+				return acc.(int) + elem.(int)
+			},
+			initial: 10,
+			a:       slices.Values([]any{1, 2, 3}),
+			expect:  16,
+		},
+		{
+			name: "fold to another type",
+			f: func(acc, elem any) any {
+				return acc.(string) + strconv.Itoa(elem.(int))
+			},
+			initial: ">",
+			a:       slices.Values([]any{1, 2, 3}),
+			expect:  ">123",
+		},
+		{
+			name: "fold empty sequence",
+			f: func(_, _ any) any {
+				return nil
+			},
+			initial: 42,
+			a:       slices.Values([]any{}),
+			expect:  42,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Fold(test.f, test.initial, test.a))
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      stdIter.Seq[any]
+		b      stdIter.Seq[any]
+		expect bool
+	}{
+		{
+			name:   "equal two slices of int",
+			a:      iter.Chain([]any{1, 2, 3}),
+			b:      iter.Chain([]any{1, 2, 3}),
+			expect: true,
+		},
+		{
+			name:   "two slices of int, len(a) > len(b)",
+			a:      iter.Chain([]any{1, 2, 3}),
+			b:      iter.Chain([]any{1, 2}),
+			expect: false,
+		},
+		{
+			name:   "two slices of int, len(a) < len(b)",
+			a:      iter.Chain([]any{1, 2}),
+			b:      iter.Chain([]any{1, 2, 3}),
+			expect: false,
+		},
+		{
+			name:   "not equal two slices of int",
+			a:      iter.Chain([]any{1, 2, 5}),
+			b:      iter.Chain([]any{1, 2, 3}),
+			expect: false,
+		},
+		{
+			name:   "not equal two slices of int, len(a) > len(b)",
+			a:      iter.Chain([]any{1, 2, 5}),
+			b:      iter.Chain([]any{1, 2}),
+			expect: false,
+		},
+		{
+			name:   "empty and non-empty",
+			a:      iter.Chain([]any{}),
+			b:      iter.Chain([]any{1}),
+			expect: false,
+		},
+		{
+			name:   "two empty slices",
+			a:      iter.Chain([]any{}),
+			b:      iter.Chain([]any{}),
+			expect: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Equal(test.a, test.b))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Parallel()
+
+	eq := func(i int, s string) bool { return strconv.Itoa(i) == s }
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []string
+		expect bool
+	}{
+		{
+			name:   "equal",
+			a:      []int{1, 2, 3},
+			b:      []string{"1", "2", "3"},
+			expect: true,
+		},
+		{
+			name:   "not equal",
+			a:      []int{1, 2, 3},
+			b:      []string{"1", "5", "3"},
+			expect: false,
+		},
+		{
+			name:   "len(a) > len(b)",
+			a:      []int{1, 2, 3},
+			b:      []string{"1", "2"},
+			expect: false,
+		},
+		{
+			name:   "len(a) < len(b)",
+			a:      []int{1, 2},
+			b:      []string{"1", "2", "3"},
+			expect: false,
+		},
+		{
+			name:   "empty",
+			expect: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.EqualFunc(slices.Values(test.a), slices.Values(test.b), eq))
+		})
+	}
+
+	t.Run("stops at the first difference", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		got := iter.EqualFunc(slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 5, 3}), func(a, b int) bool {
+			calls++
+
+			return a == b
+		})
+
+		assert.False(t, got)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestEqual2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      map[string]int
+		b      map[string]int
+		expect bool
+	}{
+		{
+			name:   "equal",
+			a:      map[string]int{"a": 1},
+			b:      map[string]int{"a": 1},
+			expect: true,
+		},
+		{
+			name:   "different values",
+			a:      map[string]int{"a": 1},
+			b:      map[string]int{"a": 2},
+			expect: false,
+		},
+		{
+			name:   "different keys",
+			a:      map[string]int{"a": 1},
+			b:      map[string]int{"b": 1},
+			expect: false,
+		},
+		{
+			name:   "len(a) > len(b)",
+			a:      map[string]int{"a": 1},
+			b:      map[string]int{},
+			expect: false,
+		},
+		{
+			name:   "len(a) < len(b)",
+			a:      map[string]int{},
+			b:      map[string]int{"a": 1},
+			expect: false,
+		},
+		{
+			name:   "empty",
+			a:      map[string]int{},
+			b:      map[string]int{},
+			expect: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Equal2(iter.ChainMap(test.a), iter.ChainMap(test.b)))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []int
+		expect int
+	}{
+		{
+			name:   "equal",
+			a:      []int{1, 2, 3},
+			b:      []int{1, 2, 3},
+			expect: 0,
+		},
+		{
+			name:   "less",
+			a:      []int{1, 2, 3},
+			b:      []int{1, 5},
+			expect: -1,
+		},
+		{
+			name:   "greater",
+			a:      []int{1, 5},
+			b:      []int{1, 2, 3},
+			expect: 1,
+		},
+		{
+			name:   "prefix is less",
+			a:      []int{1, 2},
+			b:      []int{1, 2, 3},
+			expect: -1,
+		},
+		{
+			name:   "longer is greater",
+			a:      []int{1, 2, 3},
+			b:      []int{1, 2},
+			expect: 1,
+		},
+		{
+			name:   "empty",
+			expect: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.Compare(slices.Values(test.a), slices.Values(test.b))
+
+			assert.Equal(t, test.expect, got)
+			assert.Equal(t, slices.Compare(test.a, test.b), got)
+		})
+	}
+}
+
+func TestCompareFunc(t *testing.T) {
+	t.Parallel()
+
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+
+	tests := []struct {
+		name   string
+		a      []string
+		b      []string
+		expect int
+	}{
+		{
+			name:   "equal lengths",
+			a:      []string{"a", "bb"},
+			b:      []string{"c", "dd"},
+			expect: 0,
+		},
+		{
+			name:   "less",
+			a:      []string{"a", "b"},
+			b:      []string{"a", "bb"},
+			expect: -1,
+		},
+		{
+			name:   "greater",
+			a:      []string{"aaa"},
+			b:      []string{"a", "bb"},
+			expect: 1,
+		},
+		{
+			name:   "prefix is less",
+			a:      []string{"a"},
+			b:      []string{"b", "c"},
+			expect: -1,
+		},
+		{
+			name:   "longer is greater",
+			a:      []string{"a", "b"},
+			b:      []string{"c"},
+			expect: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := iter.CompareFunc(slices.Values(test.a), slices.Values(test.b), byLen)
+
+			assert.Equal(t, test.expect, got)
+			assert.Equal(t, slices.CompareFunc(test.a, test.b, byLen), got)
 		})
 	}
 }
@@ -0,0 +1,351 @@
+package iter
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// result is the outcome of a function call made by a worker.
+type result[T any] struct {
+	val      T
+	err      error
+	panicked bool
+	panicVal any
+}
+
+// unwrap returns the value and the error of the result.
+// It panics again if the function call panicked.
+func (r result[T]) unwrap() (T, error) {
+	if r.panicked {
+		panic(r.panicVal)
+	}
+
+	return r.val, r.err
+}
+
+// call returns the result of f(ctx, elem), recovering from panics.
+func call[T, U any](ctx context.Context, f func(context.Context, T) (U, error), elem T) (res result[U]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = result[U]{panicked: true, panicVal: r}
+		}
+	}()
+
+	val, err := f(ctx, elem)
+	if err != nil {
+		var zero U
+
+		val = zero
+	}
+
+	return result[U]{val: val, err: err}
+}
+
+// job is an element of the input sequence waiting to be processed by a
+// worker, which sends the result on res.
+type job[T, U any] struct {
+	elem T
+	res  chan result[U]
+}
+
+// ParallelMap returns a fallible sequence of elements from the input
+// sequence.
+// The resulting sequence contains the elements after applying the function to
+// each element of the input sequence, in the input order.
+// The function is called concurrently by at most workers goroutines, and
+// receives a context that is cancelled when the iteration stops.
+// Errors returned by the function are yielded with the zero value, and do not
+// stop the iteration.
+// If the function, or the input sequence, panics, the panic is propagated to
+// the goroutine ranging over the resulting sequence.
+// If ctx is done, the resulting sequence yields a last pair holding the zero
+// value and the cause of the cancellation, as returned by context.Cause, then
+// stops.
+// Before the iteration returns, the workers are stopped.
+// The goroutine ranging over the input sequence is not waited for, so that an
+// input sequence waiting for its next element does not block the iteration:
+// it returns once the input sequence yields again, or returns.
+// It panics if workers is less than 1.
+func ParallelMap[T, U any](ctx context.Context, workers int, f func(context.Context, T) (U, error), seq iter.Seq[T]) iter.Seq2[U, error] {
+	if workers < 1 {
+		panic("iter: workers cannot be less than 1")
+	}
+
+	return func(yield func(U, error) bool) {
+		workCtx, cancel := context.WithCancel(ctx)
+
+		var wg sync.WaitGroup
+
+		defer wg.Wait()
+		defer cancel()
+
+		// pending holds the result channels in the input order, its capacity
+		// bounds the number of results computed ahead of the consumer.
+		pending := make(chan chan result[U], workers)
+		jobs := make(chan job[T, U])
+
+		go func() {
+			defer close(pending)
+			defer close(jobs)
+
+			feed(workCtx, seq, jobs, pending)
+		}()
+
+		wg.Add(workers)
+
+		for range workers {
+			go func() {
+				defer wg.Done()
+
+				for {
+					select {
+					case j, ok := <-jobs:
+						if !ok {
+							return
+						}
+
+						j.res <- call(workCtx, f, j.elem)
+
+					case <-workCtx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		var zero U
+
+		for {
+			var res chan result[U]
+
+			select {
+			case r, ok := <-pending:
+				if !ok {
+					if ctx.Err() != nil {
+						yield(zero, context.Cause(ctx))
+					}
+
+					return
+				}
+
+				res = r
+
+			case <-workCtx.Done():
+				yield(zero, context.Cause(ctx))
+
+				return
+			}
+
+			select {
+			case r := <-res:
+				if !yield(r.unwrap()) {
+					return
+				}
+
+			case <-workCtx.Done():
+				yield(zero, context.Cause(ctx))
+
+				return
+			}
+		}
+	}
+}
+
+// feed sends the elements of seq as jobs, and their result channels to
+// pending in the input order, until seq is exhausted or ctx is done.
+// A panic of seq is sent as a result to pending.
+func feed[T, U any](ctx context.Context, seq iter.Seq[T], jobs chan<- job[T, U], pending chan<- chan result[U]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res := make(chan result[U], 1)
+			res <- result[U]{panicked: true, panicVal: r}
+
+			select {
+			case pending <- res:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	for elem := range seq {
+		res := make(chan result[U], 1)
+
+		select {
+		case pending <- res:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case jobs <- job[T, U]{elem: elem, res: res}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ParallelMapUnordered returns a fallible sequence of elements from the input
+// sequence.
+// The resulting sequence contains the elements after applying the function to
+// each element of the input sequence, in the order the calls complete.
+// The function is called concurrently by at most workers goroutines, and
+// receives a context that is cancelled when the iteration stops.
+// At most buffer results are kept waiting for the consumer, 0 meaning that
+// each worker waits for the consumer to take its result.
+// Errors, panics, the cancellation of ctx and an input sequence waiting for
+// its next element are handled as ParallelMap does.
+// It panics if workers is less than 1, or if buffer is negative.
+func ParallelMapUnordered[T, U any](ctx context.Context, workers, buffer int, f func(context.Context, T) (U, error), seq iter.Seq[T]) iter.Seq2[U, error] {
+	return parallelUnordered(ctx, workers, buffer, func(ctx context.Context, elem T) (U, bool, error) {
+		val, err := f(ctx, elem)
+
+		return val, true, err
+	}, seq)
+}
+
+// ParallelFilterUnordered returns a fallible sequence of elements from the
+// input sequence.
+// The resulting sequence contains only the elements where the predicate is
+// true, in the order the calls complete.
+// The predicate is called concurrently by at most workers goroutines, and
+// receives a context that is cancelled when the iteration stops.
+// At most buffer results are kept waiting for the consumer, 0 meaning that
+// each worker waits for the consumer to take its result.
+// Errors, panics, the cancellation of ctx and an input sequence waiting for
+// its next element are handled as ParallelMap does.
+// It panics if workers is less than 1, or if buffer is negative.
+func ParallelFilterUnordered[T any](ctx context.Context, workers, buffer int, pred func(context.Context, T) (bool, error), seq iter.Seq[T]) iter.Seq2[T, error] {
+	return parallelUnordered(ctx, workers, buffer, func(ctx context.Context, elem T) (T, bool, error) {
+		ok, err := pred(ctx, elem)
+
+		return elem, ok, err
+	}, seq)
+}
+
+// parallelUnordered is the implementation of ParallelMapUnordered and
+// ParallelFilterUnordered.
+// The workers pull the elements of seq themselves, and send the values for
+// which f returns true, or a non-nil error, as soon as they are computed.
+// The workers are not waited for, as they may be blocked in seq: the last one
+// to return stops seq.
+func parallelUnordered[T, U any](ctx context.Context, workers, buffer int, f func(context.Context, T) (U, bool, error), seq iter.Seq[T]) iter.Seq2[U, error] {
+	if workers < 1 {
+		panic("iter: workers cannot be less than 1")
+	}
+
+	if buffer < 0 {
+		panic("iter: buffer cannot be negative")
+	}
+
+	return func(yield func(U, error) bool) {
+		workCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		next, stop := iter.Pull(seq)
+
+		// mu serializes the calls to next and stop, as iter.Pull requires.
+		var mu sync.Mutex
+
+		pull := func() (T, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if workCtx.Err() != nil {
+				var zero T
+
+				return zero, false
+			}
+
+			return next()
+		}
+
+		results := make(chan result[U], buffer)
+
+		var wg sync.WaitGroup
+
+		wg.Add(workers)
+
+		for range workers {
+			go func() {
+				defer wg.Done()
+
+				for {
+					res, ok := step(workCtx, pull, f)
+					if !ok {
+						return
+					}
+
+					select {
+					case results <- res:
+					case <-workCtx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+
+			mu.Lock()
+			stop()
+			mu.Unlock()
+
+			close(results)
+		}()
+
+		var zero U
+
+		for {
+			select {
+			case res, ok := <-results:
+				if !ok {
+					if ctx.Err() != nil {
+						yield(zero, context.Cause(ctx))
+					}
+
+					return
+				}
+
+				if !yield(res.unwrap()) {
+					return
+				}
+
+			case <-workCtx.Done():
+				yield(zero, context.Cause(ctx))
+
+				return
+			}
+		}
+	}
+}
+
+// step pulls elements until f returns true or a non-nil error for one of
+// them, and returns its result.
+// It returns false once pull does, and turns a panic of pull or f into a
+// result.
+func step[T, U any](ctx context.Context, pull func() (T, bool), f func(context.Context, T) (U, bool, error)) (res result[U], ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, ok = result[U]{panicked: true, panicVal: r}, true
+		}
+	}()
+
+	for {
+		elem, more := pull()
+		if !more {
+			return res, false
+		}
+
+		val, keep, err := f(ctx, elem)
+
+		switch {
+		case err != nil:
+			return result[U]{err: err}, true
+
+		case keep:
+			return result[U]{val: val}, true
+		}
+	}
+}
@@ -0,0 +1,554 @@
+package iter_test
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommoulard/iter"
+)
+
+var errParallel = errors.New("parallel")
+
+// assertNoGoroutineLeak returns a function checking that the number of
+// goroutines gets back to what it was when assertNoGoroutineLeak was called.
+func assertNoGoroutineLeak(t *testing.T) func() {
+	t.Helper()
+
+	before := runtime.NumGoroutine()
+
+	return func() {
+		t.Helper()
+
+		// Not using assert.Eventually as it starts its own goroutines.
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		assert.LessOrEqual(t, runtime.NumGoroutine(), before, "goroutines leaked")
+	}
+}
+
+// ints returns the slice of integers from 1 to n.
+func ints(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i + 1
+	}
+
+	return res
+}
+
+func square(_ context.Context, i int) (int, error) {
+	time.Sleep(time.Duration(rand.IntN(100)) * time.Microsecond)
+
+	return i * i, nil
+}
+
+func TestParallelMap(t *testing.T) {
+	// Not parallel: it counts goroutines.
+	defer assertNoGoroutineLeak(t)()
+
+	tests := []struct {
+		name    string
+		workers int
+		a       []int
+	}{
+		{
+			name:    "one worker",
+			workers: 1,
+			a:       ints(4),
+		},
+		{
+			name:    "more workers than elements",
+			workers: 16,
+			a:       ints(4),
+		},
+		{
+			name:    "more elements than workers",
+			workers: 4,
+			a:       ints(100),
+		},
+		{
+			name:    "empty sequence",
+			workers: 4,
+			a:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expect := iter.Values(iter.Map(func(i int) int { return i * i }, test.a))
+
+			got, err := iter.TryValues(iter.ParallelMap(t.Context(), test.workers, square, slices.Values(test.a)))
+			require.NoError(t, err)
+			assert.Equal(t, expect, got)
+		})
+	}
+}
+
+func TestParallelMap_boundedConcurrency(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	var running, maxRunning atomic.Int32
+
+	f := func(ctx context.Context, i int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		return square(ctx, i)
+	}
+
+	got, err := iter.TryValues(iter.ParallelMap(t.Context(), 3, f, slices.Values(ints(50))))
+	require.NoError(t, err)
+	assert.Len(t, got, 50)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestParallelMap_errors(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	f := func(_ context.Context, i int) (int, error) {
+		if i%2 == 0 {
+			return i, errParallel
+		}
+
+		return i, nil
+	}
+
+	got, gotErr := iter.Values2(iter.ParallelMap(t.Context(), 2, f, slices.Values(ints(4))))
+	assert.Equal(t, []int{1, 0, 3, 0}, got)
+	assert.Equal(t, []error{nil, errParallel, nil, errParallel}, gotErr)
+}
+
+func TestParallelMap_break(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	var produced atomic.Int32
+
+	source := func(yield func(int) bool) {
+		for i := 1; ; i++ {
+			produced.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	var got []int
+
+	for v, err := range iter.ParallelMap(t.Context(), 4, square, source) {
+		require.NoError(t, err)
+
+		if v > 50 {
+			break
+		}
+
+		got = append(got, v)
+	}
+
+	assert.Equal(t, []int{1, 4, 9, 16, 25, 36, 49}, got)
+	// The source is stopped shortly after the consumer, within the buffers.
+	assert.Less(t, produced.Load(), int32(20))
+}
+
+func TestParallelMap_cancelled(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	f := func(ctx context.Context, i int) (int, error) {
+		if i == 3 {
+			cancel(errParallel)
+		}
+
+		if i >= 3 {
+			<-ctx.Done()
+
+			return 0, context.Cause(ctx)
+		}
+
+		return i, nil
+	}
+
+	got, err := iter.TryValues(iter.ParallelMap(ctx, 2, f, slices.Values(ints(10))))
+	require.ErrorIs(t, err, errParallel)
+	// Results computed before the cancellation may or may not be yielded.
+	require.LessOrEqual(t, len(got), 2)
+	assert.True(t, slices.Equal(ints(2)[:len(got)], got))
+}
+
+func TestParallelMap_cancelledIdleSource(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ch := make(chan int, 1)
+	ch <- 1
+	// Closed before checking for leaks, so that the goroutine ranging over the
+	// source returns.
+	defer close(ch)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	var (
+		got    []int
+		gotErr []error
+	)
+
+	for v, err := range iter.ParallelMap(ctx, 2, square, chanSeq(ch)) {
+		got = append(got, v)
+		gotErr = append(gotErr, err)
+
+		// The source has no next element when ctx is cancelled.
+		cancel(errParallel)
+	}
+
+	assert.Equal(t, []int{1, 0}, got)
+	assert.Equal(t, []error{nil, errParallel}, gotErr)
+}
+
+func TestParallelMap_panic(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	f := func(_ context.Context, i int) (int, error) {
+		if i == 3 {
+			panic("boom")
+		}
+
+		return i, nil
+	}
+
+	var got []int
+
+	assert.PanicsWithValue(t, "boom", func() {
+		for v := range iter.ParallelMap(t.Context(), 2, f, slices.Values(ints(10))) {
+			got = append(got, v)
+		}
+	})
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestParallelMap_sourcePanic(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	source := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+
+		panic("source")
+	}
+
+	var got []int
+
+	assert.PanicsWithValue(t, "source", func() {
+		for v := range iter.ParallelMap(t.Context(), 2, square, source) {
+			got = append(got, v)
+		}
+	})
+	assert.Equal(t, []int{1}, got)
+}
+
+func TestParallelMap_invalidWorkers(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		iter.ParallelMap(t.Context(), 0, square, slices.Values(ints(1)))
+	})
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	tests := []struct {
+		name    string
+		workers int
+		buffer  int
+		a       []int
+	}{
+		{
+			name:    "one worker without buffer",
+			workers: 1,
+			buffer:  0,
+			a:       ints(10),
+		},
+		{
+			name:    "more workers than elements",
+			workers: 16,
+			buffer:  4,
+			a:       ints(4),
+		},
+		{
+			name:    "more elements than workers",
+			workers: 4,
+			buffer:  2,
+			a:       ints(100),
+		},
+		{
+			name:    "empty sequence",
+			workers: 4,
+			buffer:  4,
+			a:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expect := iter.Values(iter.Map(func(i int) int { return i * i }, test.a))
+
+			got, err := iter.TryValues(iter.ParallelMapUnordered(t.Context(), test.workers, test.buffer, square, slices.Values(test.a)))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, expect, got)
+		})
+	}
+}
+
+func TestParallelMapUnordered_completionOrder(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	f := func(_ context.Context, i int) (int, error) {
+		time.Sleep(time.Duration(i) * 20 * time.Millisecond)
+
+		return i, nil
+	}
+
+	got, err := iter.TryValues(iter.ParallelMapUnordered(t.Context(), 3, 0, f, slices.Values([]int{3, 2, 1})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestParallelMapUnordered_boundedConcurrency(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	var running, maxRunning atomic.Int32
+
+	f := func(ctx context.Context, i int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		return square(ctx, i)
+	}
+
+	got, err := iter.TryValues(iter.ParallelMapUnordered(t.Context(), 3, 1, f, slices.Values(ints(50))))
+	require.NoError(t, err)
+	assert.Len(t, got, 50)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestParallelMapUnordered_break(t *testing.T) {
+	for _, buffer := range []int{0, 1, 16} {
+		t.Run("buffer "+strconv.Itoa(buffer), func(t *testing.T) {
+			defer assertNoGoroutineLeak(t)()
+
+			var produced atomic.Int32
+
+			source := func(yield func(int) bool) {
+				for i := 1; ; i++ {
+					produced.Add(1)
+
+					if !yield(i) {
+						return
+					}
+				}
+			}
+
+			got := 0
+
+			for _, err := range iter.ParallelMapUnordered(t.Context(), 4, buffer, square, source) {
+				require.NoError(t, err)
+
+				got++
+				if got == 10 {
+					break
+				}
+			}
+
+			assert.Equal(t, 10, got)
+			// The source is stopped shortly after the consumer, within the
+			// workers and the buffer.
+			assert.LessOrEqual(t, produced.Load(), int32(10+4+buffer+1))
+		})
+	}
+}
+
+func TestParallelMapUnordered_errors(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	f := func(_ context.Context, i int) (int, error) {
+		if i%2 == 0 {
+			return i, errParallel
+		}
+
+		return i, nil
+	}
+
+	got, gotErr := iter.Values2(iter.ParallelMapUnordered(t.Context(), 2, 2, f, slices.Values(ints(4))))
+	assert.ElementsMatch(t, []int{1, 0, 3, 0}, got)
+	assert.ElementsMatch(t, []error{nil, errParallel, nil, errParallel}, gotErr)
+}
+
+func TestParallelMapUnordered_cancelled(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	f := func(ctx context.Context, i int) (int, error) {
+		if i == 3 {
+			cancel(errParallel)
+		}
+
+		if i >= 3 {
+			<-ctx.Done()
+
+			return 0, context.Cause(ctx)
+		}
+
+		return i, nil
+	}
+
+	got, err := iter.TryValues(iter.ParallelMapUnordered(ctx, 2, 0, f, slices.Values(ints(10))))
+	require.ErrorIs(t, err, errParallel)
+	assert.LessOrEqual(t, len(got), 2)
+}
+
+func TestParallelMapUnordered_cancelledIdleSource(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	ch := make(chan int, 1)
+	ch <- 1
+	// Closed before checking for leaks, so that the workers blocked in the
+	// source return.
+	defer close(ch)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
+
+	var (
+		got    []int
+		gotErr []error
+	)
+
+	for v, err := range iter.ParallelMapUnordered(ctx, 2, 0, square, chanSeq(ch)) {
+		got = append(got, v)
+		gotErr = append(gotErr, err)
+
+		// The source has no next element when ctx is cancelled.
+		cancel(errParallel)
+	}
+
+	assert.Equal(t, []int{1, 0}, got)
+	assert.Equal(t, []error{nil, errParallel}, gotErr)
+}
+
+func TestParallelMapUnordered_panic(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	f := func(_ context.Context, i int) (int, error) {
+		if i == 3 {
+			panic("boom")
+		}
+
+		return i, nil
+	}
+
+	assert.PanicsWithValue(t, "boom", func() {
+		for range iter.ParallelMapUnordered(t.Context(), 2, 1, f, slices.Values(ints(10))) {
+		}
+	})
+}
+
+func TestParallelMapUnordered_sourcePanic(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	source := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+
+		panic("source")
+	}
+
+	assert.PanicsWithValue(t, "source", func() {
+		for range iter.ParallelMapUnordered(t.Context(), 2, 1, square, source) {
+		}
+	})
+}
+
+func TestParallelMapUnordered_invalidArguments(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		iter.ParallelMapUnordered(t.Context(), 0, 0, square, slices.Values(ints(1)))
+	})
+	assert.Panics(t, func() {
+		iter.ParallelMapUnordered(t.Context(), 1, -1, square, slices.Values(ints(1)))
+	})
+}
+
+func TestParallelFilterUnordered(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	isEven := func(_ context.Context, i int) (bool, error) {
+		time.Sleep(time.Duration(rand.IntN(100)) * time.Microsecond)
+
+		return i%2 == 0, nil
+	}
+
+	got, err := iter.TryValues(iter.ParallelFilterUnordered(t.Context(), 4, 2, isEven, slices.Values(ints(20))))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}, got)
+}
+
+func TestParallelFilterUnordered_errors(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	pred := func(_ context.Context, i int) (bool, error) {
+		if i == 3 {
+			return true, errParallel
+		}
+
+		return i%2 == 0, nil
+	}
+
+	got, gotErr := iter.Values2(iter.ParallelFilterUnordered(t.Context(), 2, 0, pred, slices.Values(ints(4))))
+	assert.ElementsMatch(t, []int{2, 0, 4}, got)
+	assert.ElementsMatch(t, []error{nil, errParallel, nil}, gotErr)
+}
+
+func TestParallelFilterUnordered_break(t *testing.T) {
+	defer assertNoGoroutineLeak(t)()
+
+	isEven := func(_ context.Context, i int) (bool, error) {
+		return i%2 == 0, nil
+	}
+
+	for range iter.ParallelFilterUnordered(t.Context(), 4, 4, isEven, slices.Values(ints(1000))) {
+		break
+	}
+}
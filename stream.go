@@ -0,0 +1,160 @@
+package iter
+
+import (
+	"iter"
+	"slices"
+)
+
+// Stream is a sequence that exposes the type preserving functions of this
+// package as chainable methods.
+// As it is an iter.Seq, a Stream can be ranged over directly.
+// Functions that change the type of the elements (IMap, Chunk, …), or that
+// constrain it further (Distinct, …), are not methods: convert the Stream with
+// Seq, and back with From, as in From(Distinct(s.Seq())).
+type Stream[T any] iter.Seq[T]
+
+// From returns a Stream of elements from the input sequence.
+func From[T any](seq iter.Seq[T]) Stream[T] {
+	return Stream[T](seq)
+}
+
+// Of returns a Stream of the given elements.
+func Of[T any](elems ...T) Stream[T] {
+	return Stream[T](slices.Values(elems))
+}
+
+// Seq returns the Stream as an iter.Seq.
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return iter.Seq[T](s)
+}
+
+// Filter returns a Stream containing only the elements where the predicate is
+// true.
+func (s Stream[T]) Filter(pred func(T) bool) Stream[T] {
+	return From(IFilter(pred, s.Seq()))
+}
+
+// FilterFalse returns a Stream containing only the elements where the
+// predicate is false.
+func (s Stream[T]) FilterFalse(pred func(T) bool) Stream[T] {
+	return From(IFilterFalse(pred, s.Seq()))
+}
+
+// TakeWhile returns a Stream containing only the elements before the
+// predicate is false.
+func (s Stream[T]) TakeWhile(pred func(T) bool) Stream[T] {
+	return From(ITakeWhile(pred, s.Seq()))
+}
+
+// DropWhile returns a Stream containing only the elements after the predicate
+// is false.
+func (s Stream[T]) DropWhile(pred func(T) bool) Stream[T] {
+	return From(IDropWhile(pred, s.Seq()))
+}
+
+// Take returns a Stream containing at most the first n elements.
+func (s Stream[T]) Take(n int) Stream[T] {
+	return From(Take(s.Seq(), n))
+}
+
+// Skip returns a Stream containing the elements after the first n ones.
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return From(Skip(s.Seq(), n))
+}
+
+// Slice returns a Stream containing the elements from index start to index
+// stop, taking one element every step, as the Slice function does.
+func (s Stream[T]) Slice(start, stop, step int) Stream[T] {
+	return From(Slice(s.Seq(), start, stop, step))
+}
+
+// TakeLast returns a Stream containing at most the last n elements.
+func (s Stream[T]) TakeLast(n int) Stream[T] {
+	return From(TakeLast(s.Seq(), n))
+}
+
+// SkipLast returns a Stream containing the elements except the last n ones.
+func (s Stream[T]) SkipLast(n int) Stream[T] {
+	return From(SkipLast(s.Seq(), n))
+}
+
+// Peek returns a Stream containing the same elements, calling the function on
+// each element as it is yielded.
+func (s Stream[T]) Peek(f func(T)) Stream[T] {
+	return func(yield func(T) bool) {
+		for elem := range s {
+			f(elem)
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// SortedFunc returns a Stream containing the elements sorted using the
+// comparison function, as slices.SortedFunc does.
+// The input Stream is fully consumed before the first element is yielded.
+func (s Stream[T]) SortedFunc(cmp func(a, b T) int) Stream[T] {
+	return func(yield func(T) bool) {
+		for _, elem := range slices.SortedFunc(s.Seq(), cmp) {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns a slice of elements from the Stream.
+func (s Stream[T]) Collect() []T {
+	return Values(s.Seq())
+}
+
+// Count returns the number of elements in the Stream.
+func (s Stream[T]) Count() int {
+	return Len(s.Seq())
+}
+
+// Reduce returns the result of applying the function cumulatively to the
+// elements of the Stream, from left to right.
+// It returns false if the Stream is empty.
+func (s Stream[T]) Reduce(f func(T, T) T) (T, bool) {
+	return Reduce(f, s.Seq())
+}
+
+// First returns the first element of the Stream.
+// It returns false if the Stream is empty.
+func (s Stream[T]) First() (T, bool) {
+	for elem := range s {
+		return elem, true
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// Any returns true if the predicate is true for at least one element of the
+// Stream.
+// It stops at the first element where the predicate is true.
+func (s Stream[T]) Any(pred func(T) bool) bool {
+	for elem := range s {
+		if pred(elem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All returns true if the predicate is true for every element of the Stream.
+// It stops at the first element where the predicate is false.
+func (s Stream[T]) All(pred func(T) bool) bool {
+	for elem := range s {
+		if !pred(elem) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,202 @@
+package iter_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tommoulard/iter"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tests := []struct {
+		name   string
+		s      iter.Stream[int]
+		expect []int
+	}{
+		{
+			name:   "of",
+			s:      iter.Of(1, 2, 3),
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "from",
+			s:      iter.From(slices.Values([]int{1, 2, 3})),
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "filter",
+			s:      iter.Of(1, 2, 3, 4).Filter(isEven),
+			expect: []int{2, 4},
+		},
+		{
+			name:   "filter false",
+			s:      iter.Of(1, 2, 3, 4).FilterFalse(isEven),
+			expect: []int{1, 3},
+		},
+		{
+			name:   "take while",
+			s:      iter.Of(2, 4, 5, 6).TakeWhile(isEven),
+			expect: []int{2, 4},
+		},
+		{
+			name:   "drop while",
+			s:      iter.Of(2, 4, 5, 6).DropWhile(isEven),
+			expect: []int{5, 6},
+		},
+		{
+			name:   "take",
+			s:      iter.Of(1, 2, 3, 4).Take(3),
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "skip",
+			s:      iter.Of(1, 2, 3, 4).Skip(3),
+			expect: []int{4},
+		},
+		{
+			name:   "slice",
+			s:      iter.Of(1, 2, 3, 4, 5).Slice(1, -1, 2),
+			expect: []int{2, 4},
+		},
+		{
+			name:   "take last",
+			s:      iter.Of(1, 2, 3, 4).TakeLast(2),
+			expect: []int{3, 4},
+		},
+		{
+			name:   "skip last",
+			s:      iter.Of(1, 2, 3, 4).SkipLast(3),
+			expect: []int{1},
+		},
+		{
+			name:   "distinct",
+			s:      iter.From(iter.Distinct(iter.Of(1, 2, 1, 3, 2).Seq())),
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "sorted",
+			s:      iter.Of(3, 1, 2).SortedFunc(cmp.Compare[int]),
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "chained",
+			s:      iter.From(iter.Distinct(iter.Of(5, 4, 3, 2, 1, 2, 4).Filter(isEven).Seq())).SortedFunc(cmp.Compare[int]).Skip(1),
+			expect: []int{4},
+		},
+		{
+			name:   "empty",
+			s:      iter.Of[int](),
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, test.s.Collect())
+			assert.Equal(t, test.expect, iter.Values(test.s.Seq()))
+		})
+	}
+}
+
+func TestStream_Peek(t *testing.T) {
+	t.Parallel()
+
+	var peeked []int
+
+	got := iter.Of(1, 2, 3, 4).
+		Peek(func(i int) { peeked = append(peeked, i) }).
+		Take(2).
+		Collect()
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Equal(t, []int{1, 2}, peeked)
+}
+
+func TestStream_Count(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3, iter.Of(1, 2, 3).Count())
+	assert.Equal(t, 0, iter.Of[int]().Count())
+}
+
+func TestStream_Reduce(t *testing.T) {
+	t.Parallel()
+
+	sum := func(a, b int) int { return a + b }
+
+	got, ok := iter.Of(1, 2, 3).Reduce(sum)
+	assert.True(t, ok)
+	assert.Equal(t, 6, got)
+
+	got, ok = iter.Of[int]().Reduce(sum)
+	assert.False(t, ok)
+	assert.Zero(t, got)
+}
+
+func TestStream_First(t *testing.T) {
+	t.Parallel()
+
+	got, ok := iter.Of(4, 5, 6).First()
+	assert.True(t, ok)
+	assert.Equal(t, 4, got)
+
+	got, ok = iter.Of[int]().First()
+	assert.False(t, ok)
+	assert.Zero(t, got)
+}
+
+func TestStream_Any(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	isTwo := func(i int) bool {
+		calls++
+
+		return i == 2
+	}
+
+	assert.True(t, iter.Of(1, 2, 3).Any(isTwo))
+	assert.Equal(t, 2, calls)
+	assert.False(t, iter.Of(1, 3).Any(isTwo))
+	assert.False(t, iter.Of[int]().Any(isTwo))
+}
+
+func TestStream_All(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	isPositive := func(i int) bool {
+		calls++
+
+		return i > 0
+	}
+
+	assert.False(t, iter.Of(1, -2, 3).All(isPositive))
+	assert.Equal(t, 2, calls)
+	assert.True(t, iter.Of(1, 3).All(isPositive))
+	assert.True(t, iter.Of[int]().All(isPositive))
+}
+
+func TestStream_range(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+
+	for elem := range iter.Of(1, 2, 3, 4) {
+		if elem > 2 {
+			break
+		}
+
+		got = append(got, elem)
+	}
+
+	assert.Equal(t, []int{1, 2}, got)
+}
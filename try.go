@@ -0,0 +1,154 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// NoError returns a fallible sequence of elements from the input sequence.
+// Every element of the resulting sequence is paired with a nil error.
+func NoError[T any](seq iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for elem := range seq {
+			if !yield(elem, nil) {
+				return
+			}
+		}
+	}
+}
+
+// SkipErrors returns a sequence of elements from the input fallible sequence.
+// The resulting sequence contains only the elements paired with a nil error.
+func SkipErrors[T any](seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem, err := range seq {
+			if err == nil && !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Must returns a sequence of elements from the input fallible sequence.
+// It panics with the first non-nil error of the input sequence.
+func Must[T any](seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem, err := range seq {
+			if err != nil {
+				panic(fmt.Errorf("iter: must: %w", err))
+			}
+
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// TryMap returns a fallible sequence of elements from the input fallible
+// sequence.
+// The resulting sequence contains the elements after applying the function to
+// each element of the input sequence paired with a nil error.
+// Errors of the input sequence, and errors returned by the function, are
+// yielded with the zero value.
+func TryMap[T, U any](f func(T) (U, error), seq iter.Seq2[T, error]) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		var zero U
+
+		for elem, err := range seq {
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+
+				continue
+			}
+
+			res, err := f(elem)
+			if err != nil {
+				res = zero
+			}
+
+			if !yield(res, err) {
+				return
+			}
+		}
+	}
+}
+
+// TryFilter returns a fallible sequence of elements from the input fallible
+// sequence.
+// The resulting sequence contains only the elements where the predicate is
+// true.
+// Errors of the input sequence, and errors returned by the predicate, are
+// yielded with the zero value.
+func TryFilter[T any](pred func(T) (bool, error), seq iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for elem, err := range seq {
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+
+				continue
+			}
+
+			ok, err := pred(elem)
+
+			switch {
+			case err != nil:
+				if !yield(zero, err) {
+					return
+				}
+
+			case ok:
+				if !yield(elem, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// TryValues returns a slice of elements from the input fallible sequence.
+// It stops at the first non-nil error, and returns the elements collected so
+// far along with that error.
+func TryValues[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var res []T
+
+	for elem, err := range seq {
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, elem)
+	}
+
+	return res, nil
+}
+
+// CollectErrors returns a slice of elements from the input fallible sequence.
+// Unlike TryValues, it consumes the whole input sequence: the elements paired
+// with a nil error are collected, and the non-nil errors are joined with
+// errors.Join.
+func CollectErrors[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var (
+		res  []T
+		errs []error
+	)
+
+	for elem, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		res = append(res, elem)
+	}
+
+	return res, errors.Join(errs...)
+}
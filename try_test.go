@@ -0,0 +1,200 @@
+package iter_test
+
+import (
+	"errors"
+	stdIter "iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommoulard/iter"
+)
+
+var (
+	errOdd  = errors.New("odd")
+	errRead = errors.New("read")
+)
+
+// fallible returns a fallible sequence of the given elements, where every
+// negative element is replaced by errRead.
+func fallible(elems ...int) stdIter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, elem := range elems {
+			var err error
+			if elem < 0 {
+				elem, err = 0, errRead
+			}
+
+			if !yield(elem, err) {
+				return
+			}
+		}
+	}
+}
+
+func TestNoError(t *testing.T) {
+	t.Parallel()
+
+	gotA, gotB := iter.Values2(iter.NoError(slices.Values([]int{1, 2, 3})))
+	assert.Equal(t, []int{1, 2, 3}, gotA)
+	assert.Equal(t, []error{nil, nil, nil}, gotB)
+}
+
+func TestSkipErrors(t *testing.T) {
+	t.Parallel()
+
+	got := iter.Values(iter.SkipErrors(fallible(1, -1, 2, -1, 3)))
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestMust(t *testing.T) {
+	t.Parallel()
+
+	got := iter.Values(iter.Must(fallible(1, 2, 3)))
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	assert.PanicsWithError(t, "iter: must: read", func() {
+		iter.Values(iter.Must(fallible(1, -1, 3)))
+	})
+}
+
+func TestTryMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		seq       stdIter.Seq2[int, error]
+		expect    []string
+		expectErr []error
+	}{
+		{
+			name:      "no error",
+			seq:       fallible(2, 4),
+			expect:    []string{"2", "4"},
+			expectErr: []error{nil, nil},
+		},
+		{
+			name:      "error from the function",
+			seq:       fallible(2, 3, 4),
+			expect:    []string{"2", "", "4"},
+			expectErr: []error{nil, errOdd, nil},
+		},
+		{
+			name:      "error from the input sequence",
+			seq:       fallible(2, -1, 4),
+			expect:    []string{"2", "", "4"},
+			expectErr: []error{nil, errRead, nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := func(i int) (string, error) {
+				if i%2 == 1 {
+					return "ignored", errOdd
+				}
+
+				return strconv.Itoa(i), nil
+			}
+
+			got, gotErr := iter.Values2(iter.TryMap(f, test.seq))
+			assert.Equal(t, test.expect, got)
+			assert.Equal(t, test.expectErr, gotErr)
+		})
+	}
+}
+
+func TestTryFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		seq       stdIter.Seq2[int, error]
+		expect    []int
+		expectErr []error
+	}{
+		{
+			name:      "no error",
+			seq:       fallible(2, 4, 6),
+			expect:    []int{2, 6},
+			expectErr: []error{nil, nil},
+		},
+		{
+			name:      "error from the predicate",
+			seq:       fallible(2, 3, 6),
+			expect:    []int{2, 0, 6},
+			expectErr: []error{nil, errOdd, nil},
+		},
+		{
+			name:      "error from the input sequence",
+			seq:       fallible(2, -1, 4),
+			expect:    []int{2, 0},
+			expectErr: []error{nil, errRead},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pred := func(i int) (bool, error) {
+				if i%2 == 1 {
+					return true, errOdd
+				}
+
+				return i != 4, nil
+			}
+
+			got, gotErr := iter.Values2(iter.TryFilter(pred, test.seq))
+			assert.Equal(t, test.expect, got)
+			assert.Equal(t, test.expectErr, gotErr)
+		})
+	}
+}
+
+func TestTryValues(t *testing.T) {
+	t.Parallel()
+
+	got, err := iter.TryValues(fallible(1, 2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	consumed := 0
+	seq := func(yield func(int, error) bool) {
+		for elem, err := range fallible(1, -1, 3) {
+			consumed++
+
+			if !yield(elem, err) {
+				return
+			}
+		}
+	}
+
+	got, err = iter.TryValues(seq)
+	require.ErrorIs(t, err, errRead)
+	assert.Equal(t, []int{1}, got)
+	assert.Equal(t, 2, consumed)
+}
+
+func TestCollectErrors(t *testing.T) {
+	t.Parallel()
+
+	got, err := iter.CollectErrors(fallible(1, 2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	got, err = iter.CollectErrors(iter.TryMap(func(i int) (int, error) {
+		if i%2 == 1 {
+			return 0, errOdd
+		}
+
+		return i, nil
+	}, fallible(1, -1, 2)))
+	require.ErrorIs(t, err, errRead)
+	require.ErrorIs(t, err, errOdd)
+	assert.Equal(t, []int{2}, got)
+}
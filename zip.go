@@ -0,0 +1,528 @@
+package iter
+
+import (
+	"iter"
+	"sync"
+)
+
+// Tuple3 holds three elements, one from each sequence given to Zip3 and its
+// variants.
+type Tuple3[T, U, V any] struct {
+	A T
+	B U
+	C V
+}
+
+// Tuple4 holds four elements, one from each sequence given to Zip4 and its
+// variants.
+type Tuple4[T, U, V, W any] struct {
+	A T
+	B U
+	C V
+	D W
+}
+
+// Zip3 returns a sequence of tuples of elements from the input slices.
+// The resulting sequence is as long as the shortest input slice.
+func Zip3[T, U, V any](a []T, b []U, c []V) iter.Seq[Tuple3[T, U, V]] {
+	return func(yield func(Tuple3[T, U, V]) bool) {
+		for i := range min(len(a), len(b), len(c)) {
+			if !yield(Tuple3[T, U, V]{a[i], b[i], c[i]}) {
+				return
+			}
+		}
+	}
+}
+
+// IZip3 returns a sequence of tuples of elements from the input sequences.
+// The resulting sequence is as long as the shortest input sequence.
+func IZip3[T, U, V any](seqA iter.Seq[T], seqB iter.Seq[U], seqC iter.Seq[V]) iter.Seq[Tuple3[T, U, V]] {
+	return func(yield func(Tuple3[T, U, V]) bool) {
+		nextB, stopB := iter.Pull(seqB)
+		defer stopB()
+
+		nextC, stopC := iter.Pull(seqC)
+		defer stopC()
+
+		for a := range seqA {
+			b, okB := nextB()
+			c, okC := nextC()
+
+			if !okB || !okC || !yield(Tuple3[T, U, V]{a, b, c}) {
+				return
+			}
+		}
+	}
+}
+
+// Zip4 returns a sequence of tuples of elements from the input slices.
+// The resulting sequence is as long as the shortest input slice.
+func Zip4[T, U, V, W any](a []T, b []U, c []V, d []W) iter.Seq[Tuple4[T, U, V, W]] {
+	return func(yield func(Tuple4[T, U, V, W]) bool) {
+		for i := range min(len(a), len(b), len(c), len(d)) {
+			if !yield(Tuple4[T, U, V, W]{a[i], b[i], c[i], d[i]}) {
+				return
+			}
+		}
+	}
+}
+
+// IZip4 returns a sequence of tuples of elements from the input sequences.
+// The resulting sequence is as long as the shortest input sequence.
+func IZip4[T, U, V, W any](seqA iter.Seq[T], seqB iter.Seq[U], seqC iter.Seq[V], seqD iter.Seq[W]) iter.Seq[Tuple4[T, U, V, W]] {
+	return func(yield func(Tuple4[T, U, V, W]) bool) {
+		nextB, stopB := iter.Pull(seqB)
+		defer stopB()
+
+		nextC, stopC := iter.Pull(seqC)
+		defer stopC()
+
+		nextD, stopD := iter.Pull(seqD)
+		defer stopD()
+
+		for a := range seqA {
+			b, okB := nextB()
+			c, okC := nextC()
+			d, okD := nextD()
+
+			if !okB || !okC || !okD || !yield(Tuple4[T, U, V, W]{a, b, c, d}) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest3 returns a sequence of tuples of elements from the input slices.
+// The resulting sequence is as long as the longest input slice.
+// The missing elements of the shorter slices are filled with fillA, fillB and
+// fillC respectively.
+func ZipLongest3[T, U, V any](a []T, b []U, c []V, fillA T, fillB U, fillC V) iter.Seq[Tuple3[T, U, V]] {
+	return func(yield func(Tuple3[T, U, V]) bool) {
+		for i := range max(len(a), len(b), len(c)) {
+			res := Tuple3[T, U, V]{
+				at(a, i, fillA),
+				at(b, i, fillB),
+				at(c, i, fillC),
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// IZipLongest3 returns a sequence of tuples of elements from the input
+// sequences.
+// The resulting sequence is as long as the longest input sequence.
+// The missing elements of the shorter sequences are filled with fillA, fillB
+// and fillC respectively.
+func IZipLongest3[T, U, V any](seqA iter.Seq[T], seqB iter.Seq[U], seqC iter.Seq[V], fillA T, fillB U, fillC V) iter.Seq[Tuple3[T, U, V]] {
+	return func(yield func(Tuple3[T, U, V]) bool) {
+		nextA, stopA := pullOr(seqA, fillA)
+		defer stopA()
+
+		nextB, stopB := pullOr(seqB, fillB)
+		defer stopB()
+
+		nextC, stopC := pullOr(seqC, fillC)
+		defer stopC()
+
+		for {
+			a, okA := nextA()
+			b, okB := nextB()
+			c, okC := nextC()
+
+			if (!okA && !okB && !okC) || !yield(Tuple3[T, U, V]{a, b, c}) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest4 returns a sequence of tuples of elements from the input slices.
+// The resulting sequence is as long as the longest input slice.
+// The missing elements of the shorter slices are filled with fillA, fillB,
+// fillC and fillD respectively.
+func ZipLongest4[T, U, V, W any](a []T, b []U, c []V, d []W, fillA T, fillB U, fillC V, fillD W) iter.Seq[Tuple4[T, U, V, W]] {
+	return func(yield func(Tuple4[T, U, V, W]) bool) {
+		for i := range max(len(a), len(b), len(c), len(d)) {
+			res := Tuple4[T, U, V, W]{
+				at(a, i, fillA),
+				at(b, i, fillB),
+				at(c, i, fillC),
+				at(d, i, fillD),
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// IZipLongest4 returns a sequence of tuples of elements from the input
+// sequences.
+// The resulting sequence is as long as the longest input sequence.
+// The missing elements of the shorter sequences are filled with fillA, fillB,
+// fillC and fillD respectively.
+func IZipLongest4[T, U, V, W any](seqA iter.Seq[T], seqB iter.Seq[U], seqC iter.Seq[V], seqD iter.Seq[W], fillA T, fillB U, fillC V, fillD W) iter.Seq[Tuple4[T, U, V, W]] {
+	return func(yield func(Tuple4[T, U, V, W]) bool) {
+		nextA, stopA := pullOr(seqA, fillA)
+		defer stopA()
+
+		nextB, stopB := pullOr(seqB, fillB)
+		defer stopB()
+
+		nextC, stopC := pullOr(seqC, fillC)
+		defer stopC()
+
+		nextD, stopD := pullOr(seqD, fillD)
+		defer stopD()
+
+		for {
+			a, okA := nextA()
+			b, okB := nextB()
+			c, okC := nextC()
+			d, okD := nextD()
+
+			if (!okA && !okB && !okC && !okD) || !yield(Tuple4[T, U, V, W]{a, b, c, d}) {
+				return
+			}
+		}
+	}
+}
+
+// ZipN returns a sequence of elements from the input slices.
+// The resulting sequence contains, for each index, a new slice holding the
+// element at that index of each input slice, in the argument order.
+// It is as long as the shortest input slice, and empty without input slices.
+func ZipN[T any](a ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if len(a) == 0 {
+			return
+		}
+
+		minLen := len(a[0])
+		for i := range a {
+			minLen = min(minLen, len(a[i]))
+		}
+
+		for i := range minLen {
+			res := make([]T, len(a))
+			for j := range a {
+				res[j] = a[j][i]
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// IZipN returns a sequence of elements from the input sequences, as ZipN
+// does.
+// The resulting sequence is as long as the shortest input sequence, and empty
+// without input sequences.
+func IZipN[T any](seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+
+		nexts := make([]func() (T, bool), len(seqs))
+
+		for i := range seqs {
+			next, stop := iter.Pull(seqs[i])
+			defer stop()
+
+			nexts[i] = next
+		}
+
+		for {
+			res := make([]T, len(seqs))
+
+			for i, next := range nexts {
+				elem, ok := next()
+				if !ok {
+					return
+				}
+
+				res[i] = elem
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// at returns the element of a at index i, or fill if i is out of range.
+func at[T any](a []T, i int, fill T) T {
+	if i < len(a) {
+		return a[i]
+	}
+
+	return fill
+}
+
+// pullOr is iter.Pull, with next returning fill instead of the zero value once
+// the sequence is exhausted.
+func pullOr[T any](seq iter.Seq[T], fill T) (func() (T, bool), func()) {
+	next, stop := iter.Pull(seq)
+
+	return func() (T, bool) {
+		elem, ok := next()
+		if !ok {
+			return fill, false
+		}
+
+		return elem, true
+	}, stop
+}
+
+// Unzip returns two sequences holding the first and the second elements of
+// the pairs of the input sequence respectively, as the inverse of IZip does.
+// The two sequences share a single iteration over the input sequence, and
+// can be ranged over independently, even concurrently: the elements one of
+// them has already read, and the other has not, are buffered until the other
+// reads them or stops.
+// The input sequence is stopped once both sequences have been ranged over,
+// until exhausted or stopped by a break, or as soon as one of them is if the
+// other has not been ranged over yet: the other then only yields the elements
+// already read.
+// Unlike most sequences of this package, the resulting sequences are
+// single-use: ranging over one of them again yields nothing.
+func Unzip[T, U any](seq iter.Seq2[T, U]) (iter.Seq[T], iter.Seq[U]) {
+	u := &unzip[T, U]{seq: seq}
+
+	seqA := func(yield func(T) bool) {
+		if !u.start(&u.a.started) {
+			return
+		}
+
+		defer u.done(&u.a.done)
+
+		for {
+			elem, ok := u.nextA()
+			if !ok || !yield(elem) {
+				return
+			}
+		}
+	}
+
+	seqB := func(yield func(U) bool) {
+		if !u.start(&u.b.started) {
+			return
+		}
+
+		defer u.done(&u.b.done)
+
+		for {
+			elem, ok := u.nextB()
+			if !ok || !yield(elem) {
+				return
+			}
+		}
+	}
+
+	return seqA, seqB
+}
+
+// unzip is the state shared by the sequences returned by Unzip.
+type unzip[T, U any] struct {
+	seq iter.Seq2[T, U]
+
+	// mu serializes the calls to next and stop, as iter.Pull2 requires, and
+	// guards the fields below.
+	mu        sync.Mutex
+	next      func() (T, U, bool)
+	stop      func()
+	exhausted bool
+	a         unzipSide[T]
+	b         unzipSide[U]
+}
+
+// unzipSide is the state of one of the sequences returned by Unzip.
+type unzipSide[T any] struct {
+	started bool
+	done    bool
+	queue   []T
+}
+
+// start marks a side as started, and pulls the input sequence the first time
+// it is called.
+// It returns false if the side was already started.
+func (u *unzip[T, U]) start(started *bool) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if *started {
+		return false
+	}
+
+	*started = true
+
+	if u.next == nil {
+		u.next, u.stop = iter.Pull2(u.seq)
+	}
+
+	return true
+}
+
+// done marks a side as done, and stops the input sequence once both are, or
+// if the other side has not started.
+func (u *unzip[T, U]) done(done *bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	*done = true
+
+	switch {
+	case u.a.done && u.b.done:
+		u.a.queue, u.b.queue = nil, nil
+
+		u.stop()
+
+	case !u.a.started || !u.b.started:
+		// The side that has not started only gets its queue.
+		u.exhausted = true
+
+		u.stop()
+	}
+}
+
+// pull returns the next pair of the input sequence.
+// It must be called with mu held.
+func (u *unzip[T, U]) pull() (T, U, bool) {
+	if u.exhausted {
+		var (
+			zeroT T
+			zeroU U
+		)
+
+		return zeroT, zeroU, false
+	}
+
+	a, b, ok := u.next()
+	if !ok {
+		u.exhausted = true
+	}
+
+	return a, b, ok
+}
+
+// nextA returns the next first element, from the queue or the input sequence.
+func (u *unzip[T, U]) nextA() (T, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.a.queue) > 0 {
+		var zero T
+
+		elem := u.a.queue[0]
+		u.a.queue[0] = zero
+		u.a.queue = u.a.queue[1:]
+
+		return elem, true
+	}
+
+	a, b, ok := u.pull()
+	if ok && !u.b.done {
+		u.b.queue = append(u.b.queue, b)
+	}
+
+	return a, ok
+}
+
+// nextB returns the next second element, from the queue or the input
+// sequence.
+func (u *unzip[T, U]) nextB() (U, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.b.queue) > 0 {
+		var zero U
+
+		elem := u.b.queue[0]
+		u.b.queue[0] = zero
+		u.b.queue = u.b.queue[1:]
+
+		return elem, true
+	}
+
+	a, b, ok := u.pull()
+	if ok && !u.a.done {
+		u.a.queue = append(u.a.queue, a)
+	}
+
+	return b, ok
+}
+
+// RoundRobin returns a sequence of elements from the input sequences.
+// The resulting sequence takes one element from each input sequence in turn,
+// as Python's roundrobin recipe does: once an input sequence is exhausted, it
+// is skipped, and the others go on until they are all exhausted.
+func RoundRobin[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			active := nexts[:0]
+
+			for _, next := range nexts {
+				elem, ok := next()
+				if !ok {
+					continue
+				}
+
+				if !yield(elem) {
+					return
+				}
+
+				active = append(active, next)
+			}
+
+			nexts = active
+		}
+	}
+}
+
+// Interleave returns a sequence of elements from the input sequences.
+// The resulting sequence takes one element from each input sequence in turn,
+// and stops as soon as the input sequence whose turn it is is exhausted, so
+// that it is an exact alternation of the input sequences.
+func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+
+		nexts := make([]func() (T, bool), len(seqs))
+
+		for i := range seqs {
+			next, stop := iter.Pull(seqs[i])
+			defer stop()
+
+			nexts[i] = next
+		}
+
+		for {
+			for _, next := range nexts {
+				elem, ok := next()
+				if !ok || !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,635 @@
+package iter_test
+
+import (
+	stdIter "iter"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommoulard/iter"
+)
+
+// unbounded returns an unbounded sequence of natural numbers, which
+// increments stopped when it returns.
+func unbounded(stopped *int) stdIter.Seq[int] {
+	return func(yield func(int) bool) {
+		defer func() { *stopped++ }()
+
+		for i := 0; yield(i); i++ {
+		}
+	}
+}
+
+func TestZip3(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []string
+		c      []bool
+		expect []iter.Tuple3[int, string, bool]
+	}{
+		{
+			name:   "zip3 same length",
+			a:      []int{1, 2},
+			b:      []string{"a", "b"},
+			c:      []bool{true, false},
+			expect: []iter.Tuple3[int, string, bool]{{1, "a", true}, {2, "b", false}},
+		},
+		{
+			name:   "zip3 different lengths",
+			a:      []int{1, 2, 3},
+			b:      []string{"a"},
+			c:      []bool{true, false},
+			expect: []iter.Tuple3[int, string, bool]{{1, "a", true}},
+		},
+		{
+			name: "zip3 empty slice",
+			a:    []int{1, 2, 3},
+			b:    []string{"a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.Zip3(test.a, test.b, test.c)))
+			assert.Equal(t, test.expect, iter.Values(iter.IZip3(slices.Values(test.a), slices.Values(test.b), slices.Values(test.c))))
+		})
+	}
+}
+
+func TestZip4(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []string
+		c      []bool
+		d      []float64
+		expect []iter.Tuple4[int, string, bool, float64]
+	}{
+		{
+			name:   "zip4 same length",
+			a:      []int{1, 2},
+			b:      []string{"a", "b"},
+			c:      []bool{true, false},
+			d:      []float64{0.5, 1.5},
+			expect: []iter.Tuple4[int, string, bool, float64]{{1, "a", true, 0.5}, {2, "b", false, 1.5}},
+		},
+		{
+			name:   "zip4 different lengths",
+			a:      []int{1, 2, 3},
+			b:      []string{"a", "b"},
+			c:      []bool{true, false},
+			d:      []float64{0.5},
+			expect: []iter.Tuple4[int, string, bool, float64]{{1, "a", true, 0.5}},
+		},
+		{
+			name: "zip4 empty slice",
+			a:    []int{1, 2, 3},
+			b:    []string{"a"},
+			c:    []bool{true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.Zip4(test.a, test.b, test.c, test.d)))
+			assert.Equal(t, test.expect, iter.Values(iter.IZip4(
+				slices.Values(test.a),
+				slices.Values(test.b),
+				slices.Values(test.c),
+				slices.Values(test.d),
+			)))
+		})
+	}
+}
+
+func TestZipLongest3(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []string
+		c      []bool
+		expect []iter.Tuple3[int, string, bool]
+	}{
+		{
+			name:   "ziplongest3 same length",
+			a:      []int{1, 2},
+			b:      []string{"a", "b"},
+			c:      []bool{true, false},
+			expect: []iter.Tuple3[int, string, bool]{{1, "a", true}, {2, "b", false}},
+		},
+		{
+			name: "ziplongest3 different lengths",
+			a:    []int{1, 2, 3},
+			b:    []string{"a"},
+			c:    []bool{false, false},
+			expect: []iter.Tuple3[int, string, bool]{
+				{1, "a", false},
+				{2, "-", false},
+				{3, "-", true},
+			},
+		},
+		{
+			name:   "ziplongest3 empty slices",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.ZipLongest3(test.a, test.b, test.c, -1, "-", true)))
+			assert.Equal(t, test.expect, iter.Values(iter.IZipLongest3(
+				slices.Values(test.a),
+				slices.Values(test.b),
+				slices.Values(test.c),
+				-1, "-", true,
+			)))
+		})
+	}
+}
+
+func TestZipLongest4(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      []int
+		b      []string
+		c      []bool
+		d      []float64
+		expect []iter.Tuple4[int, string, bool, float64]
+	}{
+		{
+			name:   "ziplongest4 same length",
+			a:      []int{1},
+			b:      []string{"a"},
+			c:      []bool{false},
+			d:      []float64{0.5},
+			expect: []iter.Tuple4[int, string, bool, float64]{{1, "a", false, 0.5}},
+		},
+		{
+			name: "ziplongest4 different lengths",
+			b:    []string{"a"},
+			c:    []bool{false, false},
+			d:    []float64{0.5, 1.5, 2.5},
+			expect: []iter.Tuple4[int, string, bool, float64]{
+				{-1, "a", false, 0.5},
+				{-1, "-", false, 1.5},
+				{-1, "-", true, 2.5},
+			},
+		},
+		{
+			name:   "ziplongest4 empty slices",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, iter.Values(iter.ZipLongest4(test.a, test.b, test.c, test.d, -1, "-", true, -0.5)))
+			assert.Equal(t, test.expect, iter.Values(iter.IZipLongest4(
+				slices.Values(test.a),
+				slices.Values(test.b),
+				slices.Values(test.c),
+				slices.Values(test.d),
+				-1, "-", true, -0.5,
+			)))
+		})
+	}
+}
+
+func TestZipN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]int
+		expect [][]int
+	}{
+		{
+			name:   "zipn three slices",
+			a:      [][]int{{1, 2, 3}, {4, 5}, {6, 7, 8}},
+			expect: [][]int{{1, 4, 6}, {2, 5, 7}},
+		},
+		{
+			name:   "zipn one slice",
+			a:      [][]int{{1, 2}},
+			expect: [][]int{{1}, {2}},
+		},
+		{
+			name:   "zipn with an empty slice",
+			a:      [][]int{{1, 2}, {}},
+			expect: nil,
+		},
+		{
+			name:   "zipn no slice",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seqs := make([]stdIter.Seq[int], len(test.a))
+			for i := range test.a {
+				seqs[i] = slices.Values(test.a[i])
+			}
+
+			assert.Equal(t, test.expect, iter.Values(iter.ZipN(test.a...)))
+			assert.Equal(t, test.expect, iter.Values(iter.IZipN(seqs...)))
+		})
+	}
+}
+
+func TestZip_stopsInputSequences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		seqs    int
+		iterate func(seq stdIter.Seq[int])
+	}{
+		{
+			name: "izip3",
+			seqs: 2,
+			iterate: func(seq stdIter.Seq[int]) {
+				for range iter.IZip3(slices.Values([]int{1}), seq, seq) {
+				}
+			},
+		},
+		{
+			name: "izip4",
+			seqs: 4,
+			iterate: func(seq stdIter.Seq[int]) {
+				for range iter.IZip4(seq, seq, seq, seq) {
+					break
+				}
+			},
+		},
+		{
+			name: "izip longest 3",
+			seqs: 3,
+			iterate: func(seq stdIter.Seq[int]) {
+				for range iter.IZipLongest3(seq, seq, seq, 0, 0, 0) {
+					break
+				}
+			},
+		},
+		{
+			name: "izip longest 4",
+			seqs: 4,
+			iterate: func(seq stdIter.Seq[int]) {
+				for range iter.IZipLongest4(seq, seq, seq, seq, 0, 0, 0, 0) {
+					break
+				}
+			},
+		},
+		{
+			name: "izipn",
+			seqs: 2,
+			iterate: func(seq stdIter.Seq[int]) {
+				for range iter.IZipN(seq, seq) {
+					break
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stopped int
+
+			test.iterate(unbounded(&stopped))
+
+			assert.Equal(t, test.seqs, stopped)
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keys    []string
+		values  []int
+		expectA []string
+		expectB []int
+	}{
+		{
+			name:    "unzip pairs",
+			keys:    []string{"a", "b", "c"},
+			values:  []int{1, 2, 3},
+			expectA: []string{"a", "b", "c"},
+			expectB: []int{1, 2, 3},
+		},
+		{
+			name: "unzip empty sequence",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := iter.Unzip(iter.Zip(test.keys, test.values))
+
+			assert.Equal(t, test.expectA, iter.Values(a))
+			assert.Equal(t, test.expectB, iter.Values(b))
+		})
+	}
+
+	t.Run("unzip interleaved", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := iter.Unzip(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}))
+
+		nextA, stopA := stdIter.Pull(a)
+		defer stopA()
+
+		nextB, stopB := stdIter.Pull(b)
+		defer stopB()
+
+		var (
+			gotA []string
+			gotB []int
+		)
+
+		for _, fromA := range []bool{true, false, false, true, true, false} {
+			if fromA {
+				elem, ok := nextA()
+				require.True(t, ok)
+
+				gotA = append(gotA, elem)
+
+				continue
+			}
+
+			elem, ok := nextB()
+			require.True(t, ok)
+
+			gotB = append(gotB, elem)
+		}
+
+		assert.Equal(t, []string{"a", "b", "c"}, gotA)
+		assert.Equal(t, []int{1, 2, 3}, gotB)
+	})
+
+	t.Run("unzip concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := iter.Unzip(iter.Zip(ints(100), ints(100)))
+
+		var (
+			wg   sync.WaitGroup
+			gotA []int
+		)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			gotA = iter.Values(a)
+		}()
+
+		gotB := iter.Values(b)
+
+		wg.Wait()
+
+		assert.Equal(t, ints(100), gotA)
+		assert.Equal(t, ints(100), gotB)
+	})
+
+	t.Run("unzip break on one side", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := iter.Unzip(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}))
+
+		nextB, stopB := stdIter.Pull(b)
+		defer stopB()
+
+		elem, ok := nextB()
+		require.True(t, ok)
+		assert.Equal(t, 1, elem)
+
+		for range a {
+			break
+		}
+
+		elem, ok = nextB()
+		require.True(t, ok)
+		assert.Equal(t, 2, elem)
+	})
+
+	t.Run("unzip break before the other side starts", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := iter.Unzip(iter.Zip([]string{"a", "b", "c"}, []int{1, 2, 3}))
+
+		for range a {
+			break
+		}
+
+		assert.Equal(t, []int{1}, iter.Values(b))
+	})
+
+	t.Run("unzip stops the input sequence", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped int
+
+		a, b := iter.Unzip(iter.IZip(unbounded(&stopped), unbounded(&stopped)))
+
+		nextB, stopB := stdIter.Pull(b)
+		defer stopB()
+
+		_, ok := nextB()
+		require.True(t, ok)
+
+		for range a {
+			break
+		}
+
+		assert.Zero(t, stopped)
+
+		stopB()
+
+		assert.Equal(t, 2, stopped)
+	})
+
+	t.Run("unzip single-use", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := iter.Unzip(iter.Zip([]string{"a", "b"}, []int{1, 2}))
+
+		assert.Equal(t, []string{"a", "b"}, iter.Values(a))
+		assert.Empty(t, iter.Values(a))
+		assert.Equal(t, []int{1, 2}, iter.Values(b))
+		assert.Empty(t, iter.Values(b))
+	})
+}
+
+func TestUnzip_breakBeforeTheOtherSide(t *testing.T) {
+	// Not parallel: it counts goroutines.
+	defer assertNoGoroutineLeak(t)()
+
+	var stopped int
+
+	a, b := iter.Unzip(iter.IZip(unbounded(&stopped), unbounded(&stopped)))
+
+	for range a {
+		break
+	}
+
+	// The input sequence is stopped without ranging over b.
+	assert.Equal(t, 2, stopped)
+	assert.Equal(t, []int{0}, iter.Values(b))
+}
+
+func TestRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]string
+		expect []string
+	}{
+		{
+			name:   "roundrobin same length",
+			a:      [][]string{{"a", "b"}, {"1", "2"}},
+			expect: []string{"a", "1", "b", "2"},
+		},
+		{
+			name:   "roundrobin different lengths",
+			a:      [][]string{{"a", "b", "c"}, {"d"}, {"e", "f"}},
+			expect: []string{"a", "d", "e", "b", "f", "c"},
+		},
+		{
+			name:   "roundrobin with empty sequence",
+			a:      [][]string{{}, {"a", "b"}},
+			expect: []string{"a", "b"},
+		},
+		{
+			name:   "roundrobin no sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seqs := make([]stdIter.Seq[string], len(test.a))
+			for i := range test.a {
+				seqs[i] = slices.Values(test.a[i])
+			}
+
+			assert.Equal(t, test.expect, iter.Values(iter.RoundRobin(seqs...)))
+		})
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a      [][]string
+		expect []string
+	}{
+		{
+			name:   "interleave same length",
+			a:      [][]string{{"a", "b"}, {"1", "2"}},
+			expect: []string{"a", "1", "b", "2"},
+		},
+		{
+			name:   "interleave stops at the first exhausted sequence",
+			a:      [][]string{{"a", "b", "c"}, {"d", "e"}, {"f"}},
+			expect: []string{"a", "d", "f", "b", "e"},
+		},
+		{
+			name:   "interleave with empty sequence",
+			a:      [][]string{{"a", "b"}, {}},
+			expect: []string{"a"},
+		},
+		{
+			name:   "interleave no sequence",
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seqs := make([]stdIter.Seq[string], len(test.a))
+			for i := range test.a {
+				seqs[i] = slices.Values(test.a[i])
+			}
+
+			assert.Equal(t, test.expect, iter.Values(iter.Interleave(seqs...)))
+		})
+	}
+}
+
+func TestRoundRobin_stopsInputSequences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		iterate func(seqs ...stdIter.Seq[int]) stdIter.Seq[int]
+	}{
+		{
+			name:    "roundrobin",
+			iterate: iter.RoundRobin[int],
+		},
+		{
+			name:    "interleave",
+			iterate: iter.Interleave[int],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stopped int
+
+			got := iter.Take(test.iterate(unbounded(&stopped), unbounded(&stopped), unbounded(&stopped)), 4)
+
+			assert.Equal(t, []int{0, 0, 0, 1}, iter.Values(got))
+			assert.Equal(t, 3, stopped)
+		})
+	}
+
+	t.Run("roundrobin with exhausted sequences", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped int
+
+		got := iter.Take(iter.RoundRobin(slices.Values([]int{-1}), unbounded(&stopped), unbounded(&stopped)), 5)
+
+		assert.Equal(t, []int{-1, 0, 0, 1, 1}, iter.Values(got))
+		assert.Equal(t, 2, stopped)
+	})
+}